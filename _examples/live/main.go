@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/martinohmann/neat/live"
+	"github.com/martinohmann/neat/style"
+	"github.com/martinohmann/neat/table"
+)
+
+func main() {
+	start := time.Now()
+
+	l := live.New(
+		live.TableFunc(func() *table.Table {
+			t := table.New(nil, table.WithBorder(table.BorderStyleRounded), table.WithTitle("services")).
+				AddHeader("name", "status", "latency")
+
+			for _, name := range []string{"api", "database", "cache", "queue"} {
+				latency := time.Duration(rand.Intn(200)) * time.Millisecond
+
+				status := style.New(style.FgGreen).Sprint("up")
+				if latency > 150*time.Millisecond {
+					status = style.New(style.FgYellow).Sprint("slow")
+				}
+
+				t.AddRow(name, status, latency)
+			}
+
+			return t.AddFooter("uptime", "", time.Since(start).Round(time.Second))
+		}),
+		live.WithRefreshInterval(500*time.Millisecond),
+	)
+	defer l.Stop()
+
+	<-time.After(10 * time.Second)
+
+	l.Stop()
+
+	fmt.Println("finished")
+}
@@ -76,4 +76,32 @@ func main() {
 	t.AddRow("ten", "eleven", "twelve")
 
 	t.Render()
+
+	console.Printf("\n{bold}4. table with cells spanning multiple columns and rows\n\n")
+
+	t = table.New(os.Stdout, table.WithBorderMask(table.BorderAll))
+
+	t.AddHeader(table.Cell{Value: "NODE", RowSpan: 2}, table.Cell{Value: "NETWORK", ColSpan: 2})
+	t.AddHeader("RX", "TX")
+	t.AddRow(table.Cell{Value: "node-1", RowSpan: 2}, "10 MB/s", "2 MB/s")
+	t.AddRow("12 MB/s", "1 MB/s")
+	t.AddRow("node-2", table.Cell{Value: "unreachable", ColSpan: 2})
+
+	t.Render()
+
+	console.Printf("\n{bold}5. nested tables with title\n\n")
+
+	pods := table.New(nil, table.WithBorder(table.BorderStyleRounded))
+
+	pods.AddHeader("POD", "STATUS")
+	pods.AddRow("nginx-7c5b8d6f4-x2x9z", "Running")
+	pods.AddRow("redis-0", "Pending")
+
+	t = table.New(os.Stdout, table.WithBorder(table.BorderStyleSingle), table.WithTitle("Nodes"))
+
+	t.AddHeader("NODE", "PODS")
+	t.AddRow("node-1", pods.Renderable())
+	t.AddRow("node-2", "none")
+
+	t.Render()
 }
@@ -3,6 +3,7 @@ package bar
 import (
 	"github.com/martinohmann/neat/internal/util"
 	"github.com/martinohmann/neat/measure"
+	"github.com/martinohmann/neat/style"
 )
 
 // Bar renders a progress bar.
@@ -12,6 +13,10 @@ type Bar struct {
 	FinishedStyle  *Style
 	MaxWidth       int
 	Completed      float64
+	// Renderer is used to style the bar. Defaults to style.DefaultRenderer()
+	// if nil. It should be set to the renderer of the io.Writer the bar is
+	// written to.
+	Renderer *style.Renderer
 }
 
 // New creates a new Bar which is completed by the specified percentage.
@@ -42,15 +47,20 @@ func (b Bar) Render(width int) string {
 
 	remainingStyle, completedStyle, finishedStyle := b.getStyles()
 
+	r := b.Renderer
+	if r == nil {
+		r = style.DefaultRenderer()
+	}
+
 	if completedPerc == 100 {
-		return finishedStyle.Render(width)
+		return finishedStyle.render(r, width)
 	}
 
 	completedWidth := int(float64(width) * completedPerc / 100)
 	remainingWidth := width - completedWidth
 
-	completed := completedStyle.Render(completedWidth)
-	remaining := remainingStyle.Render(remainingWidth)
+	completed := completedStyle.render(r, completedWidth)
+	remaining := remainingStyle.render(r, remainingWidth)
 
 	return completed + remaining
 }
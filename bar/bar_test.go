@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/martinohmann/neat/measure"
+	"github.com/martinohmann/neat/style"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,3 +41,25 @@ func TestBar_Measure(t *testing.T) {
 	assert.Equal(mm(4, 10), measureBar(-1, 10))
 	assert.Equal(mm(4, 10), measureBar(20, 10))
 }
+
+func TestStyle_Render_Theme(t *testing.T) {
+	assert := assert.New(t)
+
+	defer style.Enable()()
+	defer style.SetTheme(style.Theme{"bar.complete": style.New(style.FgBlue)})()
+
+	assert.Equal("\x1b[34m──\x1b[0m", DefaultCompletedStyle.Render(2))
+	assert.Equal("──", DefaultRemainingStyle.Render(2))
+	assert.Equal("\x1b[32m──\x1b[0m", NewStyle('─', style.New(style.FgGreen)).Render(2))
+}
+
+func TestBar_Render_Renderer(t *testing.T) {
+	bar := Bar{
+		CompletedStyle: NewStyle('c', style.New(style.FgHex(0xcd0000))),
+		RemainingStyle: NewStyle('r', nil),
+		Completed:      50,
+		Renderer:       style.NewProfileRenderer(style.ProfileANSI),
+	}
+
+	assert.Equal(t, "\x1b[31mcc\x1b[0mrr", bar.Render(4))
+}
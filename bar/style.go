@@ -10,16 +10,17 @@ import (
 )
 
 // Default progress bar styles. These are used if the corresponding styles of a
-// Bar are not explicitly set.
+// Bar are not explicitly set. Their colors are taken from the active theme.
 var (
-	DefaultRemainingStyle = NewStyle('─', style.New(style.FgBlack))
-	DefaultCompletedStyle = NewStyle('─', style.New(style.FgRed))
-	DefaultFinishedStyle  = NewStyle('─', style.New(style.FgGreen))
+	DefaultRemainingStyle = NewThemeStyle('─', "bar.remaining")
+	DefaultCompletedStyle = NewThemeStyle('─', "bar.complete")
+	DefaultFinishedStyle  = NewThemeStyle('─', "bar.finished")
 )
 
 // Style is the style of a progress bar.
 type Style struct {
 	style  *style.Style
+	theme  string
 	symbol rune
 }
 
@@ -31,7 +32,17 @@ func NewStyle(symbol rune, style *style.Style) *Style {
 		panic(fmt.Sprintf("NewStyle: symbol must have a rune width of 1, got %d", width))
 	}
 
-	return &Style{style, symbol}
+	return &Style{style: style, symbol: symbol}
+}
+
+// NewThemeStyle creates a new *Style which uses the style registered under
+// name in the active theme. The theme style is looked up on every render, so
+// that theme changes take effect immediately. Will panic if symbol does not
+// have a rune width of 1.
+func NewThemeStyle(symbol rune, name string) *Style {
+	s := NewStyle(symbol, nil)
+	s.theme = name
+	return s
 }
 
 // Measure implements console.Renderable.
@@ -41,15 +52,28 @@ func (s *Style) Measure(_ int) measure.Measurement {
 
 // Render implements console.Renderable.
 func (s *Style) Render(width int) string {
+	return s.render(style.DefaultRenderer(), width)
+}
+
+// render renders the bar using r for styling.
+func (s *Style) render(r *style.Renderer, width int) string {
 	if width <= 0 {
 		return ""
 	}
 
 	bar := strings.Repeat(string(s.symbol), width)
 
-	if s.style != nil {
-		bar = s.style.Sprint(bar)
+	if st := s.getStyle(); st != nil {
+		bar = r.Sprint(st, bar)
 	}
 
 	return bar
 }
+
+func (s *Style) getStyle() *style.Style {
+	if s.style != nil || s.theme == "" {
+		return s.style
+	}
+
+	return style.ThemeStyle(s.theme)
+}
@@ -33,8 +33,10 @@ func Printf(format string, args ...interface{}) (n int, err error) {
 // Spaces are added between operands when neither is a string. It returns the
 // number of bytes written and any write error encountered.
 func Fprint(w io.Writer, args ...interface{}) (n int, err error) {
-	return wrapWriter(w, func() (int, error) {
-		return fmt.Fprint(w, styleArgs(args)...)
+	r := style.RendererFor(w)
+
+	return wrapWriter(r, w, func() (int, error) {
+		return fmt.Fprint(w, styleArgs(r, args)...)
 	})
 }
 
@@ -42,16 +44,20 @@ func Fprint(w io.Writer, args ...interface{}) (n int, err error) {
 // Spaces are always added between operands and a newline is appended. It
 // returns the number of bytes written and any write error encountered.
 func Fprintln(w io.Writer, args ...interface{}) (n int, err error) {
-	return wrapWriter(w, func() (int, error) {
-		return fmt.Fprintln(w, styleArgs(args)...)
+	r := style.RendererFor(w)
+
+	return wrapWriter(r, w, func() (int, error) {
+		return fmt.Fprintln(w, styleArgs(r, args)...)
 	})
 }
 
 // Fprintf formats according to a format specifier and writes to w. It returns
 // the number of bytes written and any write error encountered.
 func Fprintf(w io.Writer, format string, args ...interface{}) (n int, err error) {
-	return wrapWriter(w, func() (int, error) {
-		return fmt.Fprintf(w, style.StyleString(format), styleArgs(args)...)
+	r := style.RendererFor(w)
+
+	return wrapWriter(r, w, func() (int, error) {
+		return fmt.Fprintf(w, r.StyleString(format), styleArgs(r, args)...)
 	})
 }
 
@@ -59,8 +65,10 @@ func Fprintf(w io.Writer, format string, args ...interface{}) (n int, err error)
 // resulting string. Spaces are added between operands when neither is a
 // string.
 func Sprint(args ...interface{}) string {
-	return wrapString(func() string {
-		return fmt.Sprint(styleArgs(args)...)
+	r := style.DefaultRenderer()
+
+	return wrapString(r, func() string {
+		return fmt.Sprint(styleArgs(r, args)...)
 	})
 }
 
@@ -68,41 +76,45 @@ func Sprint(args ...interface{}) string {
 // resulting string. Spaces are always added between operands and a newline is
 // appended.
 func Sprintln(args ...interface{}) string {
-	return wrapString(func() string {
-		return fmt.Sprintln(styleArgs(args)...)
+	r := style.DefaultRenderer()
+
+	return wrapString(r, func() string {
+		return fmt.Sprintln(styleArgs(r, args)...)
 	})
 }
 
 // Sprintf formats according to a format specifier and returns the resulting
 // string.
 func Sprintf(format string, args ...interface{}) string {
-	return wrapString(func() string {
-		return fmt.Sprintf(style.StyleString(format), styleArgs(args)...)
+	r := style.DefaultRenderer()
+
+	return wrapString(r, func() string {
+		return fmt.Sprintf(r.StyleString(format), styleArgs(r, args)...)
 	})
 }
 
-func wrapWriter(w io.Writer, fn func() (int, error)) (n int, err error) {
+func wrapWriter(r *style.Renderer, w io.Writer, fn func() (int, error)) (n int, err error) {
 	n, err = fn()
 	if err != nil {
 		return
 	}
 
-	nn, err := style.ResetWriter(w)
+	nn, err := r.ResetWriter(w)
 	n += nn
 	return
 }
 
-func wrapString(fn func() string) string {
-	return fn() + style.ResetString()
+func wrapString(r *style.Renderer, fn func() string) string {
+	return fn() + r.ResetString()
 }
 
-func styleArgs(args []interface{}) []interface{} {
+func styleArgs(r *style.Renderer, args []interface{}) []interface{} {
 	for i, arg := range args {
 		switch v := arg.(type) {
 		case style.Attribute:
-			args[i] = style.EscapeString(v)
+			args[i] = r.EscapeString(v)
 		case string:
-			args[i] = style.StyleString(v)
+			args[i] = r.StyleString(v)
 		}
 	}
 
@@ -124,7 +136,7 @@ func NewPrinter(w io.Writer) *Printer {
 // string. It returns the number of bytes written and any write error
 // encountered.
 func (p *Printer) Print(args ...interface{}) (n int, err error) {
-	return Fprint(p, args...)
+	return Fprint(p.Writer, args...)
 }
 
 // Println formats using the default formats for its operands and writes to to
@@ -132,12 +144,12 @@ func (p *Printer) Print(args ...interface{}) (n int, err error) {
 // newline is appended. It returns the number of bytes written and any write
 // error encountered.
 func (p *Printer) Println(args ...interface{}) (n int, err error) {
-	return Fprintln(p, args...)
+	return Fprintln(p.Writer, args...)
 }
 
 // Printf formats according to a format specifier and writes to the underlying
 // io.Writer. It returns the number of bytes written and any write error
 // encountered.
 func (p *Printer) Printf(format string, args ...interface{}) (n int, err error) {
-	return Fprintf(p, format, args...)
+	return Fprintf(p.Writer, format, args...)
 }
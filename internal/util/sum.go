@@ -0,0 +1,10 @@
+package util
+
+// SumInt returns the sum of all values.
+func SumInt(values ...int) (sum int) {
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum
+}
@@ -0,0 +1,249 @@
+package live
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/martinohmann/neat/console"
+	"github.com/martinohmann/neat/internal/util"
+	"github.com/martinohmann/neat/table"
+	"github.com/martinohmann/neat/text"
+)
+
+// RenderFunc builds the console.Renderable that is displayed on every redraw.
+type RenderFunc func() console.Renderable
+
+// TableFunc returns a RenderFunc which displays the *table.Table built by fn.
+// The io.Writer of the table is ignored.
+func TableFunc(fn func() *table.Table) RenderFunc {
+	return func() console.Renderable {
+		return fn().Renderable()
+	}
+}
+
+// Live redraws the output of a RenderFunc in place, e.g. for watch-style
+// status screens. Only lines that changed since the previous redraw are
+// rewritten to avoid flicker.
+//
+// The output is rendered using the terminal width and is cut off at the
+// terminal height, since lines that scrolled out of the terminal cannot be
+// redrawn anymore. If the terminal shrinks between two redraws, the whole
+// display is redrawn.
+type Live struct {
+	out      console.FileWriter
+	render   RenderFunc
+	interval time.Duration
+
+	// size returns the terminal width and height.
+	size func() (width, height int)
+
+	refreshCh chan struct{}
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+
+	stopped int32
+	once    sync.Once
+
+	// lines are the lines of the previous redraw and width is the terminal
+	// width at that time.
+	lines []string
+	width int
+}
+
+// New creates a new *Live which displays the output of render using opts. The
+// display is drawn immediately and then redrawn periodically until Stop is
+// called.
+func New(render RenderFunc, opts ...Option) *Live {
+	l := &Live{
+		render:    render,
+		interval:  1 * time.Second,
+		refreshCh: make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+
+	for _, option := range opts {
+		option(l)
+	}
+
+	if l.out == nil {
+		l.out = os.Stdout
+	}
+
+	l.size = func() (int, int) {
+		return console.TerminalSize(l.out)
+	}
+
+	go l.run()
+
+	return l
+}
+
+// Refresh requests a redraw of the display. It does not block. Multiple
+// requests that arrive before the next redraw are coalesced.
+func (l *Live) Refresh() {
+	select {
+	case l.refreshCh <- struct{}{}:
+	default:
+		// A redraw is already pending.
+	}
+}
+
+// Stop draws the display a last time and stops redrawing it. Should be
+// called using defer to ensure that the terminal cursor is reset after the
+// display is finished or the program was interrupted. Stop blocks until the
+// last redraw is finished. It is safe to call Stop multiple times, subsequent
+// calls are no-op.
+func (l *Live) Stop() {
+	if atomic.CompareAndSwapInt32(&l.stopped, 0, 1) {
+		close(l.stopCh)
+	}
+
+	<-l.doneCh
+}
+
+// Stopped returns true if the display is stopped.
+func (l *Live) Stopped() bool {
+	return atomic.LoadInt32(&l.stopped) == 1
+}
+
+func (l *Live) run() {
+	cursor := &terminal.Cursor{Out: l.out}
+
+	defer func() {
+		l.update(cursor)
+		cursor.HorizontalAbsolute(0)
+		cursor.Show()
+		close(l.doneCh)
+	}()
+
+	cursor.Hide()
+
+	l.update(cursor)
+
+	var tick <-chan time.Time
+
+	if l.interval > 0 {
+		ticker := time.NewTicker(l.interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-l.refreshCh:
+			l.update(cursor)
+		case <-tick:
+			l.update(cursor)
+		case <-l.stopCh:
+			return
+		}
+	}
+}
+
+// update renders the display and draws it to the terminal.
+func (l *Live) update(cursor *terminal.Cursor) {
+	width, height := l.size()
+
+	var lines []string
+
+	if r := l.render(); r != nil {
+		w := util.MinInt(width, r.Measure(width).Maximum)
+		lines = text.SplitLines(r.Render(w))
+	}
+
+	l.draw(cursor, lines, width, height)
+}
+
+// draw moves the cursor to the start of the previously drawn lines and
+// rewrites the lines that changed. The cursor must be located below the
+// previously drawn lines and is placed below the new lines afterwards. Lines
+// exceeding the terminal height are cut off.
+func (l *Live) draw(cursor *terminal.Cursor, lines []string, width, height int) {
+	// The cursor occupies the line below the display, so at most height-1
+	// lines can be redrawn.
+	maxLines := util.MaxInt(0, height-1)
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+
+	up := len(l.lines)
+	redraw := false
+
+	if width < l.width {
+		// Lines that are wider than the shrunk terminal may have been
+		// wrapped by the terminal and now occupy multiple lines.
+		up = physicalLines(l.lines, width)
+		redraw = true
+	}
+
+	if up > maxLines {
+		// The top lines scrolled out of the terminal and cannot be reached
+		// anymore.
+		up = maxLines
+		redraw = true
+	}
+
+	cursor.HorizontalAbsolute(0)
+	if up > 0 {
+		cursor.Up(up)
+	}
+
+	skip := 0
+
+	for i, line := range lines {
+		if !redraw && i < len(l.lines) && l.lines[i] == line {
+			skip++
+			continue
+		}
+
+		if skip > 0 {
+			cursor.Down(skip)
+			skip = 0
+		}
+
+		terminal.EraseLine(l.out, terminal.ERASE_LINE_ALL)
+		fmt.Fprintln(l.out, line)
+	}
+
+	if skip > 0 {
+		cursor.Down(skip)
+	}
+
+	// Clear the remaining lines of the previous display.
+	if remaining := up - len(lines); remaining > 0 {
+		for i := 0; i < remaining; i++ {
+			if i > 0 {
+				cursor.Down(1)
+			}
+
+			terminal.EraseLine(l.out, terminal.ERASE_LINE_ALL)
+		}
+
+		if remaining > 1 {
+			cursor.Up(remaining - 1)
+		}
+	}
+
+	l.lines, l.width = lines, width
+}
+
+// physicalLines returns the number of terminal lines occupied by lines if the
+// terminal wraps them at width.
+func physicalLines(lines []string, width int) (n int) {
+	for _, line := range lines {
+		w := text.DisplayWidth(line)
+		if w <= width || width <= 0 {
+			n++
+		} else {
+			n += (w + width - 1) / width
+		}
+	}
+
+	return n
+}
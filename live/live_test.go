@@ -0,0 +1,95 @@
+package live
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/martinohmann/neat/table"
+	"github.com/stretchr/testify/assert"
+)
+
+type fileWriter struct {
+	bytes.Buffer
+}
+
+func (*fileWriter) Fd() uintptr { return 0 }
+
+// replacer makes escape sequences readable.
+var replacer = strings.NewReplacer(
+	"\x1b[2K", "<erase>",
+	"\x1b[0G", "",
+	"\x1b[?25l", "<hide>",
+	"\x1b[?25h", "<show>",
+	"\x1b[", "<",
+)
+
+func drawn(out *fileWriter) string {
+	s := replacer.Replace(out.String())
+	out.Reset()
+	return s
+}
+
+func TestLive_draw(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &fileWriter{}
+	cursor := &terminal.Cursor{Out: out}
+	l := &Live{out: out}
+
+	l.draw(cursor, []string{"foo", "bar", "baz"}, 80, 25)
+	assert.Equal("<erase>foo\n<erase>bar\n<erase>baz\n", drawn(out))
+
+	// Only changed lines are rewritten.
+	l.draw(cursor, []string{"foo", "qux", "baz"}, 80, 25)
+	assert.Equal("<3A<1B<erase>qux\n<1B", drawn(out))
+
+	// Stale lines are cleared.
+	l.draw(cursor, []string{"foo"}, 80, 25)
+	assert.Equal("<3A<1B<erase><1B<erase><1A", drawn(out))
+
+	// Lines exceeding the terminal height are cut off.
+	l.draw(cursor, []string{"foo", "bar", "baz"}, 80, 3)
+	assert.Equal("<1A<1B<erase>bar\n", drawn(out))
+	assert.Equal([]string{"foo", "bar"}, l.lines)
+}
+
+func TestLive_draw_terminalShrink(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &fileWriter{}
+	cursor := &terminal.Cursor{Out: out}
+	l := &Live{out: out}
+
+	l.draw(cursor, []string{"foobar", "baz"}, 80, 25)
+	drawn(out)
+
+	// The first line was wrapped by the terminal and occupies two lines now,
+	// all lines are redrawn.
+	l.draw(cursor, []string{"foo", "baz"}, 4, 25)
+	assert.Equal("<3A<erase>foo\n<erase>baz\n<erase>", drawn(out))
+
+	// The terminal height shrank below the display height.
+	l.draw(cursor, []string{"foo", "baz"}, 4, 2)
+	assert.Equal("<1A<erase>foo\n", drawn(out))
+}
+
+func TestLive(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &fileWriter{}
+
+	var rendered []string
+
+	l := New(TableFunc(func() *table.Table {
+		rendered = append(rendered, "x")
+		return table.New(nil).AddRow("foo", strings.Join(rendered, ""))
+	}), WithOutput(out), WithRefreshInterval(0))
+
+	l.Stop()
+	l.Stop()
+
+	assert.True(l.Stopped())
+	assert.Equal("<hide><erase>foo x\n<1A<erase>foo xx\n<show>", replacer.Replace(out.String()))
+}
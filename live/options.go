@@ -0,0 +1,27 @@
+package live
+
+import (
+	"time"
+
+	"github.com/martinohmann/neat/console"
+)
+
+// Option is a func for configuring a *Live.
+type Option func(l *Live)
+
+// WithOutput sets the output for the live display. If omitted, os.Stdout will
+// be used.
+func WithOutput(out console.FileWriter) Option {
+	return func(l *Live) {
+		l.out = out
+	}
+}
+
+// WithRefreshInterval sets the interval at which the live display is redrawn.
+// If interval is <= 0, the display is only redrawn when Refresh is called.
+// Defaults to 1 second.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(l *Live) {
+		l.interval = interval
+	}
+}
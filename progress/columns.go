@@ -24,7 +24,8 @@ var (
 
 // Column is a column displayed for the progress of a given task.
 type Column interface {
-	// Render renders task into a console.Renderable.
+	// Render renders task into a console.Renderable. Styles should be
+	// applied using the renderer returned by task.Renderer.
 	Render(task *Task) console.Renderable
 }
 
@@ -41,20 +42,32 @@ type TextFunc func(*Task) string
 type TextColumn struct {
 	Alignment text.Alignment
 	Style     *style.Style
-	TextFunc  TextFunc
-	Text      string
-	WordWrap  bool
+	// ThemeStyle is the name of the style in the active theme that is used
+	// if Style is nil.
+	ThemeStyle string
+	TextFunc   TextFunc
+	Text       string
+	WordWrap   bool
 }
 
 func (c TextColumn) Render(task *Task) console.Renderable {
 	return text.Text{
 		Alignment: c.Alignment,
-		Style:     c.Style,
+		Style:     c.style(),
 		Text:      c.text(task),
 		WordWrap:  c.WordWrap,
+		Renderer:  task.Renderer(),
 	}
 }
 
+func (c TextColumn) style() *style.Style {
+	if c.Style != nil || c.ThemeStyle == "" {
+		return c.Style
+	}
+
+	return style.ThemeStyle(c.ThemeStyle)
+}
+
 func (c TextColumn) text(task *Task) string {
 	if c.TextFunc != nil {
 		return c.TextFunc(task)
@@ -81,8 +94,8 @@ func NewTextFuncColumn(fn TextFunc, style *style.Style, alignment text.Alignment
 
 func NewDescriptionColumn() TextColumn {
 	return TextColumn{
-		Style:     style.New(style.Bold),
-		Alignment: text.AlignRight,
+		ThemeStyle: "progress.description",
+		Alignment:  text.AlignRight,
 		TextFunc: func(task *Task) string {
 			return task.Description()
 		},
@@ -91,8 +104,8 @@ func NewDescriptionColumn() TextColumn {
 
 func NewProgressColumn() TextColumn {
 	return TextColumn{
-		Style:     style.New(style.FgCyan),
-		Alignment: text.AlignRight,
+		ThemeStyle: "progress.progress",
+		Alignment:  text.AlignRight,
 		TextFunc: func(task *Task) string {
 			digits := util.CountDigitsInt64(task.Total())
 			return fmt.Sprintf("%*d/%d", digits, task.Completed(), task.Total())
@@ -111,8 +124,8 @@ func NewPercentageColumn() TextColumn {
 
 func NewETAColumn() TextColumn {
 	return TextColumn{
-		Style:     style.New(style.FgGreen),
-		Alignment: text.AlignRight,
+		ThemeStyle: "progress.eta",
+		Alignment:  text.AlignRight,
 		TextFunc: func(task *Task) string {
 			return fmt.Sprintf("%s ETA", formatDuration(task.Estimated()))
 		},
@@ -143,5 +156,6 @@ func (c BarColumn) Render(task *Task) console.Renderable {
 		FinishedStyle:  c.FinishedStyle,
 		MaxWidth:       c.MaxWidth,
 		Completed:      task.PercentCompleted(),
+		Renderer:       task.Renderer(),
 	}
 }
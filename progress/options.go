@@ -2,6 +2,7 @@ package progress
 
 import (
 	"github.com/martinohmann/neat/console"
+	"github.com/martinohmann/neat/style"
 	"github.com/martinohmann/neat/table"
 )
 
@@ -16,6 +17,14 @@ func WithOutput(out console.FileWriter) Option {
 	}
 }
 
+// WithRenderer sets the renderer that is used to style the progress. Defaults
+// to the renderer for the output, see style.RendererFor.
+func WithRenderer(r *style.Renderer) Option {
+	return func(p *Progress) {
+		p.renderer = r
+	}
+}
+
 // WithColumns sets the columns that the progress should render for each task.
 // If omitted, DefaultColumns will be used.
 func WithColumns(columns ...Column) Option {
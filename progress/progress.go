@@ -8,12 +8,14 @@ import (
 
 	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/martinohmann/neat/console"
+	"github.com/martinohmann/neat/style"
 	"github.com/martinohmann/neat/table"
 )
 
 // Progress manages and display task progress information.
 type Progress struct {
-	out console.FileWriter
+	out      console.FileWriter
+	renderer *style.Renderer
 
 	columns      []Column
 	tableOptions []table.Option
@@ -47,6 +49,10 @@ func New(opts ...Option) *Progress {
 		p.out = os.Stdout
 	}
 
+	if p.renderer == nil {
+		p.renderer = style.RendererFor(p.out)
+	}
+
 	if p.columns == nil {
 		p.columns = DefaultColumns
 	}
@@ -150,7 +156,9 @@ func (p *Progress) update(cursor *terminal.Cursor) {
 		cursor.Up(p.displayHeight)
 	}
 
-	table := table.New(p.out, p.tableOptions...)
+	opts := append([]table.Option{table.WithRenderer(p.renderer)}, p.tableOptions...)
+
+	table := table.New(p.out, opts...)
 
 	for _, task := range p.tasks {
 		if !task.Started() {
@@ -3,6 +3,8 @@ package progress
 import (
 	"sync"
 	"time"
+
+	"github.com/martinohmann/neat/style"
 )
 
 // timeNow be overwritten with a fake now provider in tests.
@@ -61,6 +63,16 @@ func (t *Task) Description() string {
 	return t.desc
 }
 
+// Renderer returns the renderer of the progress the task belongs to. Columns
+// should use it to style their output.
+func (t *Task) Renderer() *style.Renderer {
+	if t.progress == nil || t.progress.renderer == nil {
+		return style.DefaultRenderer()
+	}
+
+	return t.progress.renderer
+}
+
 // Completed returns the number of completed parts of the task. This is always
 // less than or equal to the total.
 func (t *Task) Completed() int64 {
@@ -4,14 +4,21 @@ import "strconv"
 
 // Attribute is an attribute of an ANSI escape sequence.
 type Attribute interface {
-	sequence() string
+	// sequence returns the escape sequence parameters of the attribute for
+	// the color profile p. Returns an empty string if the attribute is not
+	// supported by p.
+	sequence(p Profile) string
 }
 
 // SimpleAttribute is an attribute that is just one uint8 value.
 type SimpleAttribute uint8
 
 // sequence implements Attribute.
-func (a SimpleAttribute) sequence() string {
+func (a SimpleAttribute) sequence(p Profile) string {
+	if p == ProfileNoColor && isColor(a) {
+		return ""
+	}
+
 	return strconv.Itoa(int(a))
 }
 
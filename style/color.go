@@ -0,0 +1,264 @@
+package style
+
+import (
+	"fmt"
+	"math"
+)
+
+// Color is a 24-bit RGB color. It provides conversions from and to other
+// color models and operations for deriving colors from each other, e.g. to
+// build color scales:
+//
+//	scale := style.Gradient(5, style.Hex(0x00ff00), style.Hex(0xff0000))
+//	scale[2].Fg().Sprint("warning")
+type Color struct {
+	R, G, B uint8
+}
+
+// RGB creates a Color from its red, green and blue channels.
+func RGB(r, g, b uint8) Color {
+	return Color{r, g, b}
+}
+
+// Hex creates a Color from a hex value like 0xff8800.
+func Hex(v uint32) Color {
+	return Color{uint8(v >> 16), uint8(v >> 8), uint8(v)}
+}
+
+// ParseHex parses a hex color of the form "#ff8800" or "#f80". The leading
+// "#" is optional.
+func ParseHex(s string) (Color, error) {
+	if len(s) > 0 && s[0] == '#' {
+		s = s[1:]
+	}
+
+	v, ok := parseHex(s)
+	if !ok {
+		return Color{}, fmt.Errorf("invalid hex color %q", s)
+	}
+
+	return Hex(v), nil
+}
+
+// Color256 returns the Color of the entry at index in the xterm 256 color
+// palette.
+func Color256(index uint8) Color {
+	return palette256[index]
+}
+
+// HSL creates a Color from hue in degrees and saturation and lightness in the
+// range [0, 1].
+func HSL(h, s, l float64) Color {
+	h, s, l = normalizeHue(h), clamp(s), clamp(l)
+
+	c := (1 - math.Abs(2*l-1)) * s
+
+	return fromHueChroma(h, c, l-c/2)
+}
+
+// HSV creates a Color from hue in degrees and saturation and value in the
+// range [0, 1].
+func HSV(h, s, v float64) Color {
+	h, s, v = normalizeHue(h), clamp(s), clamp(v)
+
+	c := v * s
+
+	return fromHueChroma(h, c, v-c)
+}
+
+// fromHueChroma creates a Color from hue, chroma and the value m that is
+// added to each channel.
+func fromHueChroma(h, c, m float64) Color {
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+
+	var r, g, b float64
+
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return Color{toByte(r + m), toByte(g + m), toByte(b + m)}
+}
+
+// Hex returns the hex value of c, e.g. 0xff8800.
+func (c Color) Hex() uint32 {
+	return uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
+}
+
+// String implements fmt.Stringer. It returns c as hex string, e.g. "#ff8800".
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// HSL returns the hue of c in degrees and its saturation and lightness in the
+// range [0, 1].
+func (c Color) HSL() (h, s, l float64) {
+	max, min := c.bounds()
+	h = c.hue()
+	l = (max + min) / 2
+
+	if d := max - min; d > 0 {
+		s = d / (1 - math.Abs(2*l-1))
+	}
+
+	return h, s, l
+}
+
+// HSV returns the hue of c in degrees and its saturation and value in the
+// range [0, 1].
+func (c Color) HSV() (h, s, v float64) {
+	max, min := c.bounds()
+
+	if max > 0 {
+		s = (max - min) / max
+	}
+
+	return c.hue(), s, max
+}
+
+// Index256 returns the index of the color in the xterm 256 color palette
+// which is perceptually closest to c.
+func (c Color) Index256() uint8 {
+	return uint8(nearest(c, 16, 256))
+}
+
+// Lighten returns c with its lightness increased by amount, e.g. 0.1 for
+// 10 percentage points.
+func (c Color) Lighten(amount float64) Color {
+	h, s, l := c.HSL()
+	return HSL(h, s, l+amount)
+}
+
+// Darken returns c with its lightness decreased by amount.
+func (c Color) Darken(amount float64) Color {
+	return c.Lighten(-amount)
+}
+
+// Saturate returns c with its saturation increased by amount. Negative
+// amounts desaturate c.
+func (c Color) Saturate(amount float64) Color {
+	h, s, l := c.HSL()
+	return HSL(h, s+amount, l)
+}
+
+// Complement returns the color with the opposite hue of c.
+func (c Color) Complement() Color {
+	h, s, l := c.HSL()
+	return HSL(h+180, s, l)
+}
+
+// Blend mixes c with other. A weight of 0 returns c, a weight of 1 returns
+// other.
+func (c Color) Blend(other Color, weight float64) Color {
+	weight = clamp(weight)
+
+	mix := func(a, b uint8) uint8 {
+		return toByte((float64(a) + (float64(b)-float64(a))*weight) / 255)
+	}
+
+	return Color{mix(c.R, other.R), mix(c.G, other.G), mix(c.B, other.B)}
+}
+
+// Fg creates a *Style with c as foreground color.
+func (c Color) Fg() *Style {
+	return FgRGB(c.R, c.G, c.B)
+}
+
+// Bg creates a *Style with c as background color.
+func (c Color) Bg() *Style {
+	return BgRGB(c.R, c.G, c.B)
+}
+
+// Gradient returns n colors that are evenly spaced between the stops. The
+// first and last color are the first and last stop. Returns nil if n < 1 or
+// there are no stops.
+func Gradient(n int, stops ...Color) []Color {
+	if n < 1 || len(stops) == 0 {
+		return nil
+	}
+
+	colors := make([]Color, n)
+
+	if n == 1 || len(stops) == 1 {
+		for i := range colors {
+			colors[i] = stops[0]
+		}
+
+		return colors
+	}
+
+	segments := float64(len(stops) - 1)
+
+	for i := range colors {
+		pos := float64(i) / float64(n-1) * segments
+
+		stop := int(pos)
+		if stop >= len(stops)-1 {
+			stop = len(stops) - 2
+		}
+
+		colors[i] = stops[stop].Blend(stops[stop+1], pos-float64(stop))
+	}
+
+	return colors
+}
+
+// bounds returns the largest and smallest channel of c in the range [0, 1].
+func (c Color) bounds() (max, min float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+
+	return math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+}
+
+// hue returns the hue of c in degrees.
+func (c Color) hue() float64 {
+	max, min := c.bounds()
+
+	d := max - min
+	if d == 0 {
+		return 0
+	}
+
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+
+	var h float64
+
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+
+	return normalizeHue(h * 60)
+}
+
+func normalizeHue(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	return h
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func toByte(v float64) uint8 {
+	return uint8(math.Round(clamp(v) * 255))
+}
@@ -0,0 +1,81 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColor_Conversions(t *testing.T) {
+	assert := assert.New(t)
+
+	c := Hex(0xff8800)
+
+	assert.Equal(RGB(255, 136, 0), c)
+	assert.Equal(uint32(0xff8800), c.Hex())
+	assert.Equal("#ff8800", c.String())
+
+	h, s, l := c.HSL()
+	assert.InDelta(32, h, 0.01)
+	assert.InDelta(1, s, 0.01)
+	assert.InDelta(0.5, l, 0.01)
+	assert.Equal(c, HSL(h, s, l))
+
+	h, s, v := c.HSV()
+	assert.InDelta(32, h, 0.01)
+	assert.InDelta(1, s, 0.01)
+	assert.InDelta(1, v, 0.01)
+	assert.Equal(c, HSV(h, s, v))
+
+	assert.Equal(RGB(128, 128, 128), HSL(0, 0, 0.5))
+	assert.Equal(RGB(0, 0, 255), HSV(-120, 1, 1))
+
+	assert.Equal(uint8(208), c.Index256())
+	assert.Equal(RGB(255, 135, 0), Color256(208))
+
+	parsed, err := ParseHex("#f80")
+	if assert.NoError(err) {
+		assert.Equal(c, parsed)
+	}
+
+	_, err = ParseHex("#ff88")
+	assert.EqualError(err, `invalid hex color "ff88"`)
+}
+
+func TestColor_Operations(t *testing.T) {
+	assert := assert.New(t)
+
+	red := RGB(255, 0, 0)
+
+	assert.Equal(RGB(255, 102, 102), red.Lighten(0.2))
+	assert.Equal(RGB(153, 0, 0), red.Darken(0.2))
+	assert.Equal(RGB(255, 255, 255), red.Lighten(1))
+	assert.Equal(RGB(191, 64, 64), red.Saturate(-0.5))
+	assert.Equal(RGB(0, 255, 255), red.Complement())
+	assert.Equal(RGB(128, 0, 128), red.Blend(RGB(0, 0, 255), 0.5))
+	assert.Equal(red, red.Blend(RGB(0, 0, 255), -1))
+}
+
+func TestGradient(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Gradient(0, RGB(0, 0, 0)))
+	assert.Nil(Gradient(3))
+	assert.Equal([]Color{RGB(1, 2, 3), RGB(1, 2, 3)}, Gradient(2, RGB(1, 2, 3)))
+	assert.Equal([]Color{RGB(0, 0, 0)}, Gradient(1, RGB(0, 0, 0), RGB(255, 255, 255)))
+	assert.Equal(
+		[]Color{RGB(0, 0, 0), RGB(128, 128, 128), RGB(255, 255, 255)},
+		Gradient(3, RGB(0, 0, 0), RGB(255, 255, 255)),
+	)
+	assert.Equal(
+		[]Color{RGB(0, 255, 0), RGB(128, 255, 0), RGB(255, 255, 0), RGB(255, 128, 0), RGB(255, 0, 0)},
+		Gradient(5, Hex(0x00ff00), Hex(0xffff00), Hex(0xff0000)),
+	)
+}
+
+func TestColor_Style(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(FgRGB(255, 136, 0), Hex(0xff8800).Fg())
+	assert.Equal(BgRGB(255, 136, 0), Hex(0xff8800).Bg())
+}
@@ -0,0 +1,180 @@
+package style
+
+import (
+	"math"
+	"strconv"
+)
+
+// lab is a color in the CIELAB color space which is designed so that the
+// euclidean distance between two colors approximates their perceived
+// difference.
+type lab struct {
+	l, a, b float64
+}
+
+var (
+	// ansiPalette contains the default xterm colors of the 16 basic and
+	// hi-intensity colors.
+	ansiPalette = [16]Color{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+		{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+
+	// palette256 contains the colors of the xterm 256 color palette.
+	palette256 = makePalette256()
+
+	// paletteLab contains palette256 converted to CIELAB.
+	paletteLab = makePaletteLab()
+)
+
+// cubeLevels are the channel values of the 6x6x6 color cube of the 256 color
+// palette.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+func makePalette256() (p [256]Color) {
+	copy(p[:], ansiPalette[:])
+
+	for i := 0; i < 216; i++ {
+		p[16+i] = Color{cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]}
+	}
+
+	for i := 0; i < 24; i++ {
+		v := uint8(8 + 10*i)
+		p[232+i] = Color{v, v, v}
+	}
+
+	return p
+}
+
+func makePaletteLab() (p [256]lab) {
+	for i, c := range palette256 {
+		p[i] = c.lab()
+	}
+
+	return p
+}
+
+// lab converts c to CIELAB using the D65 white point.
+func (c Color) lab() lab {
+	linear := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.04045 {
+			return f / 12.92
+		}
+
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+
+	r, g, b := linear(c.R), linear(c.G), linear(c.B)
+
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+
+		return (24389.0/27*t + 16) / 116
+	}
+
+	fx, fy, fz := f(x), f(y), f(z)
+
+	return lab{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
+
+// distance returns the squared perceptual distance between c and o.
+func (c lab) distance(o lab) float64 {
+	dl, da, db := c.l-o.l, c.a-o.a, c.b-o.b
+	return dl*dl + da*da + db*db
+}
+
+// nearest returns the index of the color in paletteLab[from:to] which is
+// perceptually closest to c.
+func nearest(c Color, from, to int) int {
+	target := c.lab()
+	best, bestDist := from, math.Inf(1)
+
+	for i := from; i < to; i++ {
+		if d := target.distance(paletteLab[i]); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	return best
+}
+
+// colorSequence returns the sequence of the foreground or background color
+// given by the color mode and its values for profile p. values contains the
+// red, green and blue channels for colorModeRGB or the palette index for
+// colorMode256.
+func colorSequence(attr, mode SimpleAttribute, values []SimpleAttribute, p Profile) string {
+	var c Color
+
+	switch mode {
+	case colorModeRGB:
+		c = Color{uint8(values[0]), uint8(values[1]), uint8(values[2])}
+
+		switch p {
+		case ProfileTrueColor:
+			return joinAttributes(attr, mode, values[0], values[1], values[2])
+		case ProfileANSI256:
+			return joinAttributes(attr, colorMode256, SimpleAttribute(nearest(c, 16, 256)))
+		}
+	default:
+		index := int(values[0])
+		c = palette256[index]
+
+		switch {
+		case p == ProfileTrueColor || p == ProfileANSI256:
+			return joinAttributes(attr, mode, values[0])
+		case p == ProfileANSI && index < 16:
+			return ansiSequence(attr, index)
+		}
+	}
+
+	if p == ProfileNoColor {
+		return ""
+	}
+
+	return ansiSequence(attr, nearest(c, 0, 16))
+}
+
+// ansiSequence returns the sequence of the basic or hi-intensity color with
+// the palette index for the foreground or background color attribute.
+func ansiSequence(attr SimpleAttribute, index int) string {
+	base := FgBlack
+	if attr == BgColor {
+		base = BgBlack
+	}
+
+	if index >= 8 {
+		base += FgHiBlack - FgBlack
+		index -= 8
+	}
+
+	return strconv.Itoa(int(base) + index)
+}
+
+func joinAttributes(attrs ...SimpleAttribute) string {
+	buf := make([]byte, 0, 4*len(attrs))
+
+	for i, attr := range attrs {
+		if i > 0 {
+			buf = append(buf, ';')
+		}
+
+		buf = strconv.AppendInt(buf, int64(attr), 10)
+	}
+
+	return string(buf)
+}
+
+// isColor returns true if a is a foreground or background color attribute.
+func isColor(a SimpleAttribute) bool {
+	return (a >= FgBlack && a <= FgDefault) || (a >= BgBlack && a <= BgDefault) ||
+		(a >= FgHiBlack && a <= FgHiWhite) || (a >= BgHiBlack && a <= BgHiWhite)
+}
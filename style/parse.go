@@ -0,0 +1,322 @@
+package style
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseError is returned by Parse if a style spec is invalid.
+type ParseError struct {
+	// Spec is the style spec that was parsed.
+	Spec string
+	// Token is the part of Spec that could not be parsed.
+	Token string
+	// Reason describes why Token is invalid.
+	Reason string
+}
+
+// Error implements error.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid style %q: %s %q", e.Spec, e.Reason, e.Token)
+}
+
+// Parse parses a human-readable style spec into a *Style. A spec is a list of
+// tokens separated by whitespace or commas. Supported tokens are:
+//
+//	bold, red, bgred, ...  any name defined in AttributeMap
+//	#ff8800, #f80          a foreground RGB color
+//	208                    a foreground 256 color
+//	fg:<color>             a foreground color given by name, hex or number
+//	bg:<color>             a background color given by name, hex or number
+//	on <color>             same as bg:<color>
+//	sgr:<n>                a raw SGR parameter
+//
+// Color names are the names of the basic and hi-intensity colors, e.g. "blue"
+// or "hiblue", and "default". A spec may set at most one foreground and one
+// background color. Returns a *ParseError if spec is invalid.
+func Parse(spec string) (*Style, error) {
+	tokens := strings.FieldsFunc(strings.ToLower(spec), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+
+	s := New()
+
+	var hasFg, hasBg bool
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		var (
+			attrs []Attribute
+			err   string
+		)
+
+		switch {
+		case token == "on":
+			if i+1 == len(tokens) {
+				return nil, &ParseError{Spec: spec, Token: token, Reason: "missing color after"}
+			}
+
+			i++
+			token = tokens[i]
+			attrs, err = parseColor(token, true)
+		case strings.HasPrefix(token, "fg:"):
+			attrs, err = parseColor(token[3:], false)
+		case strings.HasPrefix(token, "bg:"):
+			attrs, err = parseColor(token[3:], true)
+		case strings.HasPrefix(token, "sgr:"):
+			attrs, err = parseSGR(token[4:])
+		case strings.HasPrefix(token, "#") || isNumber(token):
+			attrs, err = parseColor(token, false)
+		default:
+			if attr, ok := AttributeMap[token]; ok {
+				attrs = []Attribute{attr}
+			} else if attrs, _ = parseColor(token, false); attrs == nil {
+				err = "unknown attribute"
+			}
+		}
+
+		fg, bg := colors(attrs)
+
+		switch {
+		case fg && hasFg:
+			err = "duplicate foreground color"
+		case bg && hasBg:
+			err = "duplicate background color"
+		}
+
+		if err != "" {
+			return nil, &ParseError{Spec: spec, Token: token, Reason: err}
+		}
+
+		hasFg, hasBg = hasFg || fg, hasBg || bg
+
+		s.add(attrs)
+	}
+
+	return s, nil
+}
+
+// MustParse is like Parse but panics if spec is invalid.
+func MustParse(spec string) *Style {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// parseColor parses a color given by name, hex value or 256 color number.
+// Returns the reason if the color is invalid.
+func parseColor(color string, bg bool) ([]Attribute, string) {
+	switch {
+	case strings.HasPrefix(color, "#"):
+		v, ok := parseHex(color[1:])
+		if !ok {
+			return nil, "invalid hex color"
+		}
+
+		if bg {
+			return []Attribute{BgHex(v)}, ""
+		}
+
+		return []Attribute{FgHex(v)}, ""
+	case isNumber(color):
+		n, err := strconv.ParseUint(color, 10, 8)
+		if err != nil {
+			return nil, "invalid 256 color"
+		}
+
+		if bg {
+			return []Attribute{Bg256(uint8(n))}, ""
+		}
+
+		return []Attribute{Fg256(uint8(n))}, ""
+	}
+
+	prefix := "fg"
+	if bg {
+		prefix = "bg"
+	}
+
+	if attr, ok := AttributeMap[prefix+strings.TrimPrefix(color, prefix)]; ok {
+		return []Attribute{attr}, ""
+	}
+
+	return nil, "unknown color"
+}
+
+// colors reports whether attrs set a foreground or a background color.
+func colors(attrs []Attribute) (fg, bg bool) {
+	s := New(attrs...)
+
+	for i := 0; i < len(s.attrs); i++ {
+		if n := s.colorLen(i); n > 0 {
+			fg, bg = fg || s.attrs[i] == FgColor, bg || s.attrs[i] == BgColor
+			i += n - 1
+			continue
+		}
+
+		if attr, ok := s.attrs[i].(SimpleAttribute); ok && isColor(attr) {
+			fg, bg = fg || !isBgColor(attr), bg || isBgColor(attr)
+		}
+	}
+
+	return fg, bg
+}
+
+// isBgColor returns true if a is a basic or hi-intensity background color.
+func isBgColor(a SimpleAttribute) bool {
+	return (a >= BgBlack && a <= BgDefault) || (a >= BgHiBlack && a <= BgHiWhite)
+}
+
+// parseHex parses colors of the form "ff8800" or "f80".
+func parseHex(s string) (uint32, bool) {
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+
+	if len(s) != 6 {
+		return 0, false
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+
+	return uint32(v), err == nil
+}
+
+func parseSGR(s string) ([]Attribute, string) {
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return nil, "invalid SGR parameter"
+	}
+
+	return []Attribute{SimpleAttribute(n)}, ""
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the spec of s in the format understood by Parse, e.g.
+// "bold #ff8800 on blue". Attributes come first, followed by the foreground
+// and the background color.
+func (s *Style) String() string {
+	names := attributeNames()
+
+	var attrs, fg, bg []string
+
+	for i := 0; i < len(s.attrs); i++ {
+		if n := s.colorLen(i); n > 0 {
+			if s.attrs[i] == BgColor {
+				bg = append(bg, s.colorString(i, n))
+			} else {
+				fg = append(fg, s.colorString(i, n))
+			}
+
+			i += n - 1
+			continue
+		}
+
+		attr, ok := s.attrs[i].(SimpleAttribute)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case isBgColor(attr):
+			bg = append(bg, "on "+names[attr-10])
+		case isColor(attr):
+			fg = append(fg, names[attr])
+		case names[attr] != "":
+			attrs = append(attrs, names[attr])
+		default:
+			attrs = append(attrs, "sgr:"+strconv.Itoa(int(attr)))
+		}
+	}
+
+	return strings.Join(append(append(attrs, fg...), bg...), " ")
+}
+
+// colorString returns the spec of the n attributes of the 256 or RGB color
+// starting at index i.
+func (s *Style) colorString(i, n int) string {
+	var color string
+
+	if n == 3 {
+		color = s.attrs[i+2].sequence(ProfileTrueColor)
+	} else {
+		r, g, b := s.attrs[i+2].(SimpleAttribute), s.attrs[i+3].(SimpleAttribute), s.attrs[i+4].(SimpleAttribute)
+		color = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+
+	switch {
+	case s.attrs[i] == BgColor:
+		return "on " + color
+	case n == 3:
+		return "fg:" + color
+	default:
+		return color
+	}
+}
+
+// attributeNames returns the canonical name of each SimpleAttribute in
+// AttributeMap, that is its shortest name. Foreground colors are named
+// without "fg" prefix, e.g. "hired".
+func attributeNames() (names [256]string) {
+	keys := make([]string, 0, len(AttributeMap))
+	for name := range AttributeMap {
+		keys = append(keys, name)
+	}
+
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		attr, ok := AttributeMap[name].(SimpleAttribute)
+		if !ok {
+			continue
+		}
+
+		if (attr >= FgBlack && attr <= FgDefault) || (attr >= FgHiBlack && attr <= FgHiWhite) {
+			name = strings.TrimPrefix(name, "fg")
+		}
+
+		if prev := names[attr]; prev == "" || len(name) < len(prev) {
+			names[attr] = name
+		}
+	}
+
+	return names
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. See Parse for the
+// supported format.
+func (s *Style) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = *parsed
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. See Style.String for the
+// format.
+func (s *Style) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,109 @@
+package style
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected *Style
+	}{
+		{"", New()},
+		{"bold underline #ff8800 on blue", New(Bold, Underline, FgHex(0xff8800), BgBlue)},
+		{"fg:208 bg:default italic", New(Fg256(208), BgDefault, Italic)},
+		{"Bold, RED", New(Bold, FgRed)},
+		{"#f80 on #000", New(FgHex(0xff8800), BgHex(0x000000))},
+		{"fg:hiblue bg:bgred", New(FgHiBlue, BgRed)},
+		{"on 17 sgr:11", New(Bg256(17), AltFont1)},
+	}
+
+	for _, test := range tests {
+		s, err := Parse(test.spec)
+		if assert.NoError(t, err, test.spec) {
+			assert.Equal(t, test.expected, s, test.spec)
+		}
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"bold foo", `invalid style "bold foo": unknown attribute "foo"`},
+		{"bold on", `invalid style "bold on": missing color after "on"`},
+		{"on bold", `invalid style "on bold": unknown color "bold"`},
+		{"#ff88", `invalid style "#ff88": invalid hex color "#ff88"`},
+		{"fg:256", `invalid style "fg:256": invalid 256 color "fg:256"`},
+		{"sgr:x", `invalid style "sgr:x": invalid SGR parameter "sgr:x"`},
+		{"red blue", `invalid style "red blue": duplicate foreground color "blue"`},
+		{"bold #f80 fg:208", `invalid style "bold #f80 fg:208": duplicate foreground color "fg:208"`},
+		{"on red bg:blue", `invalid style "on red bg:blue": duplicate background color "bg:blue"`},
+		{"fg:hiblue 12", `invalid style "fg:hiblue 12": duplicate foreground color "12"`},
+		{"bgred on #0000ff", `invalid style "bgred on #0000ff": duplicate background color "#0000ff"`},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.spec)
+		assert.EqualError(t, err, test.expected)
+	}
+
+	var parseErr *ParseError
+
+	_, err := Parse("bold foo")
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, &ParseError{Spec: "bold foo", Token: "foo", Reason: "unknown attribute"}, parseErr)
+}
+
+func TestStyle_String(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", New().String())
+	assert.Equal("bold underline #ff8800 on blue", New(Bold, Underline, FgHex(0xff8800), BgBlue).String())
+	assert.Equal("italic fg:208 on default", New(Fg256(208), BgDefault, Italic).String())
+	assert.Equal("strike sgr:11 hired on 17 on #0000ff", New(FgHiRed, Bg256(17), BgRGB(0, 0, 255), CrossedOut, AltFont1).String())
+
+	for _, spec := range []string{
+		"bold underline #ff8800 on blue",
+		"italic fg:208 on default",
+		"blink reversevideo sgr:11 hired on 17",
+	} {
+		s, err := Parse(spec)
+		if assert.NoError(err) {
+			assert.Equal(spec, s.String())
+		}
+	}
+
+	s, err := Parse("fg:208 bg:default italic")
+	if assert.NoError(err) {
+		assert.Equal("italic fg:208 on default", s.String())
+	}
+}
+
+func TestStyle_UnmarshalText(t *testing.T) {
+	assert := assert.New(t)
+
+	var config struct {
+		Error *Style `json:"error"`
+		Info  Style  `json:"info"`
+	}
+
+	err := json.Unmarshal([]byte(`{"error":"bold red","info":"fg:33"}`), &config)
+	if assert.NoError(err) {
+		assert.Equal(New(Bold, FgRed), config.Error)
+		assert.Equal(*New(Fg256(33)), config.Info)
+	}
+
+	err = json.Unmarshal([]byte(`{"error":"bold foo"}`), &config)
+	assert.EqualError(err, `invalid style "bold foo": unknown attribute "foo"`)
+
+	buf, err := json.Marshal(config.Error)
+	if assert.NoError(err) {
+		assert.Equal(`"bold red"`, string(buf))
+	}
+}
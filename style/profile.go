@@ -0,0 +1,125 @@
+package style
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// Profile describes the colors a terminal is capable of displaying.
+type Profile int
+
+const (
+	// ProfileTrueColor supports 24-bit RGB colors.
+	ProfileTrueColor Profile = iota
+	// ProfileANSI256 supports the 256 colors of the xterm palette. RGB
+	// colors are mapped to the nearest palette color.
+	ProfileANSI256
+	// ProfileANSI supports the 16 basic and hi-intensity colors. RGB and
+	// 256 colors are mapped to the nearest basic color.
+	ProfileANSI
+	// ProfileNoColor does not support colors. Color attributes are omitted,
+	// other attributes like bold or underline are still emitted.
+	ProfileNoColor
+)
+
+var profileNames = map[Profile]string{
+	ProfileTrueColor: "truecolor",
+	ProfileANSI256:   "ansi256",
+	ProfileANSI:      "ansi",
+	ProfileNoColor:   "nocolor",
+}
+
+// String implements fmt.Stringer.
+func (p Profile) String() string {
+	if name, ok := profileNames[p]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// colorProfile is the color profile that is used by renderers without a
+// fixed profile. It is detected from the environment on startup.
+var colorProfile = int32(DetectProfile())
+
+// ColorProfile returns the color profile that is used by renderers without a
+// fixed profile, see NewProfileRenderer.
+func ColorProfile() Profile { return Profile(atomic.LoadInt32(&colorProfile)) }
+
+// SetColorProfile sets the color profile that is used by renderers without a
+// fixed profile. The returned func can be used in combination with defer to
+// restore the previous profile.
+func SetColorProfile(p Profile) func() {
+	old := atomic.SwapInt32(&colorProfile, int32(p))
+
+	return func() { atomic.StoreInt32(&colorProfile, old) }
+}
+
+// DetectProfile detects the color profile of the terminal from the
+// COLORTERM, TERM_PROGRAM and TERM environment variables. If NO_COLOR is set,
+// ProfileNoColor is returned. FORCE_COLOR=1, 2 or 3 selects ProfileANSI,
+// ProfileANSI256 or ProfileTrueColor respectively.
+func DetectProfile() Profile {
+	return detectProfile(os.Getenv)
+}
+
+func detectProfile(getenv func(string) string) Profile {
+	if getenv("NO_COLOR") != "" {
+		return ProfileNoColor
+	}
+
+	force := getenv("FORCE_COLOR")
+
+	switch force {
+	case "1":
+		return ProfileANSI
+	case "2":
+		return ProfileANSI256
+	case "3":
+		return ProfileTrueColor
+	}
+
+	p := detectTermProfile(getenv)
+	if p == ProfileNoColor && force != "" && force != "0" && force != "false" {
+		// Colors are forced for a terminal that does not seem to support
+		// them, so fall back to the basic colors.
+		return ProfileANSI
+	}
+
+	return p
+}
+
+func detectTermProfile(getenv func(string) string) Profile {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "vscode", "Hyper", "WezTerm":
+		return ProfileTrueColor
+	case "Apple_Terminal":
+		return ProfileANSI256
+	}
+
+	term := strings.ToLower(getenv("TERM"))
+
+	switch {
+	case term == "dumb":
+		return ProfileNoColor
+	case term == "" && runtime.GOOS == "windows":
+		// Windows 10 consoles support RGB colors and colorable takes care
+		// of older ones.
+		return ProfileTrueColor
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"),
+		strings.Contains(term, "direct"), strings.Contains(term, "kitty"),
+		strings.Contains(term, "alacritty"):
+		return ProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return ProfileANSI256
+	default:
+		return ProfileANSI
+	}
+}
@@ -0,0 +1,68 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected Profile
+	}{
+		{map[string]string{"COLORTERM": "truecolor", "TERM": "xterm"}, ProfileTrueColor},
+		{map[string]string{"COLORTERM": "24bit"}, ProfileTrueColor},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app", "TERM": "xterm-256color"}, ProfileTrueColor},
+		{map[string]string{"TERM_PROGRAM": "Apple_Terminal", "TERM": "xterm"}, ProfileANSI256},
+		{map[string]string{"TERM": "xterm-kitty"}, ProfileTrueColor},
+		{map[string]string{"TERM": "xterm-256color"}, ProfileANSI256},
+		{map[string]string{"TERM": "screen-256color"}, ProfileANSI256},
+		{map[string]string{"TERM": "screen"}, ProfileANSI},
+		{map[string]string{"TERM": "xterm"}, ProfileANSI},
+		{map[string]string{"TERM": "dumb"}, ProfileNoColor},
+		{map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, ProfileNoColor},
+		{map[string]string{"FORCE_COLOR": "2", "COLORTERM": "truecolor"}, ProfileANSI256},
+		{map[string]string{"FORCE_COLOR": "true", "TERM": "dumb"}, ProfileANSI},
+	}
+
+	for _, test := range tests {
+		getenv := func(key string) string { return test.env[key] }
+
+		assert.Equal(t, test.expected, detectProfile(getenv), "env: %v", test.env)
+	}
+}
+
+func TestStyle_Sprint_Profiles(t *testing.T) {
+	defer Enable()()
+	assert := assert.New(t)
+
+	s := New(Bold, FgRGB(255, 135, 0), Bg256(21))
+
+	defer SetColorProfile(ProfileTrueColor)()
+	assert.Equal("\x1b[1;38;2;255;135;0;48;5;21mfoo\x1b[0m", s.Sprint("foo"))
+
+	SetColorProfile(ProfileANSI256)
+	assert.Equal("\x1b[1;38;5;208;48;5;21mfoo\x1b[0m", s.Sprint("foo"))
+
+	SetColorProfile(ProfileANSI)
+	assert.Equal("\x1b[1;31;44mfoo\x1b[0m", s.Sprint("foo"))
+	assert.Equal("\x1b[32mfoo\x1b[0m", New(Fg256(2)).Sprint("foo"))
+	assert.Equal("\x1b[97mfoo\x1b[0m", New(FgHex(0xfafafa)).Sprint("foo"))
+	assert.Equal("\x1b[30mfoo\x1b[0m", New(FgHex(0x101010)).Sprint("foo"))
+
+	SetColorProfile(ProfileNoColor)
+	assert.Equal("\x1b[1mfoo\x1b[0m", s.Sprint("foo"))
+	assert.Equal("foo\x1b[0m", New(FgRed).Sprint("foo"))
+}
+
+func TestStyleString_Profiles(t *testing.T) {
+	defer Enable()()
+	assert := assert.New(t)
+
+	defer SetColorProfile(ProfileTrueColor)()
+	assert.Equal("\x1b[31;1mfoo", StyleString("{red,bold}foo"))
+
+	SetColorProfile(ProfileNoColor)
+	assert.Equal("\x1b[1mfoo", StyleString("{red,bold}foo"))
+}
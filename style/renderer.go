@@ -0,0 +1,186 @@
+package style
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	colorsAuto int32 = iota
+	colorsForceEnabled
+	colorsForceDisabled
+)
+
+var (
+	// colorsOverride overrides the decision of all renderers whether colors
+	// are enabled. It is set by Enable and Disable.
+	colorsOverride int32
+
+	// renderers caches the renderers of file descriptors.
+	renderers sync.Map
+)
+
+// Renderer creates escape sequences for a specific output. It decides
+// whether the output is colorized and downsamples colors to the color
+// profile of the output. Renderers are safe for concurrent use.
+type Renderer struct {
+	enabled bool
+
+	// profile is only used if fixedProfile is true. Otherwise the current
+	// color profile is used, see ColorProfile.
+	profile      Profile
+	fixedProfile bool
+}
+
+// NewRenderer creates a new *Renderer for w. Colors are enabled if w is a
+// terminal whose TERM is not "dumb". Setting CLICOLOR=0 disables colors,
+// FORCE_COLOR or CLICOLOR_FORCE enable them even if w is not a terminal.
+// FORCE_COLOR=0 disables colors. See DetectProfile for the selection of the
+// color profile.
+func NewRenderer(w io.Writer) *Renderer {
+	return &Renderer{enabled: detectEnabled(os.Getenv, isTerminalWriter(w))}
+}
+
+// NewProfileRenderer creates a new *Renderer which always colorizes its
+// output using color profile p regardless of the environment.
+func NewProfileRenderer(p Profile) *Renderer {
+	return &Renderer{enabled: true, profile: p, fixedProfile: true}
+}
+
+// RendererFor returns the *Renderer for w. Renderers of terminals are cached
+// by their file descriptor.
+func RendererFor(w io.Writer) *Renderer {
+	if w == Stdout {
+		// Stdout wraps os.Stdout and does not expose its file descriptor on
+		// all platforms.
+		w = os.Stdout
+	}
+
+	fw, ok := w.(fileWriter)
+	if !ok {
+		return NewRenderer(w)
+	}
+
+	if r, ok := renderers.Load(fw.Fd()); ok {
+		return r.(*Renderer)
+	}
+
+	r, _ := renderers.LoadOrStore(fw.Fd(), NewRenderer(w))
+
+	return r.(*Renderer)
+}
+
+// DefaultRenderer returns the *Renderer for stdout. It is used by all
+// functions that do not write to an io.Writer, e.g. Style.Sprint.
+func DefaultRenderer() *Renderer {
+	return RendererFor(os.Stdout)
+}
+
+// Enabled returns true if r colorizes its output.
+func (r *Renderer) Enabled() bool {
+	switch atomic.LoadInt32(&colorsOverride) {
+	case colorsForceEnabled:
+		return true
+	case colorsForceDisabled:
+		return false
+	default:
+		return r.enabled
+	}
+}
+
+// Profile returns the color profile of r.
+func (r *Renderer) Profile() Profile {
+	if r.fixedProfile {
+		return r.profile
+	}
+
+	return ColorProfile()
+}
+
+// EscapeString creates the escape sequence for given attribute and returns it.
+// Colors are downsampled to the color profile of r. If coloring is disabled
+// or the attribute is not supported by the color profile this returns an
+// empty string.
+func (r *Renderer) EscapeString(attr Attribute) string {
+	if !r.Enabled() {
+		return ""
+	}
+
+	return escapeSequence(attr, r.Profile())
+}
+
+// EscapeWriter creates the escape sequence for given attribute and writes to
+// w. It returns the number of bytes written and any write error encountered.
+// If coloring is disabled this is a no-op.
+func (r *Renderer) EscapeWriter(w io.Writer, attr Attribute) (n int, err error) {
+	return io.WriteString(w, r.EscapeString(attr))
+}
+
+// ResetString creates the escape sequence for resetting all style attributes
+// and returns it. If coloring is disabled this returns an empty string.
+func (r *Renderer) ResetString() string {
+	return r.EscapeString(Reset)
+}
+
+// ResetWriter creates the escape sequence for resetting all style attributes
+// and writes to w. It returns the number of bytes written and any write error
+// encountered. If coloring is disabled this is a no-op.
+func (r *Renderer) ResetWriter(w io.Writer) (n int, err error) {
+	return r.EscapeWriter(w, Reset)
+}
+
+// StyleString is like the package level StyleString but uses r to create the
+// escape sequences.
+func (r *Renderer) StyleString(s string) string {
+	return styleString(r, s)
+}
+
+// Sprint formats using the default formats for its operands, styles the
+// result with s and returns it. Spaces are added between operands when
+// neither is a string.
+func (r *Renderer) Sprint(s *Style, args ...interface{}) string {
+	return r.EscapeString(s) + fmt.Sprint(args...) + r.ResetString()
+}
+
+// escapeSequence creates the escape sequence for attr using color profile p.
+func escapeSequence(attr Attribute, p Profile) string {
+	seq := attr.sequence(p)
+	if seq == "" {
+		return ""
+	}
+
+	return escape + "[" + seq + "m"
+}
+
+// detectEnabled decides whether colors are enabled for an output based on
+// the environment and whether the output is a terminal.
+func detectEnabled(getenv func(string) string, terminal bool) bool {
+	if force := getenv("FORCE_COLOR"); force != "" {
+		return force != "0" && force != "false"
+	}
+
+	if force := getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+
+	if !terminal || getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return getenv("CLICOLOR") != "0"
+}
+
+// fileWriter is an io.Writer which provides access to its file descriptor.
+type fileWriter interface {
+	io.Writer
+	Fd() uintptr
+}
+
+func isTerminalWriter(w io.Writer) bool {
+	fw, ok := w.(fileWriter)
+
+	return ok && isTerminal(fw.Fd())
+}
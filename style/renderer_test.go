@@ -0,0 +1,66 @@
+package style
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectEnabled(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		terminal bool
+		expected bool
+	}{
+		{map[string]string{}, true, true},
+		{map[string]string{}, false, false},
+		{map[string]string{"TERM": "dumb"}, true, false},
+		{map[string]string{"CLICOLOR": "0"}, true, false},
+		{map[string]string{"CLICOLOR_FORCE": "1"}, false, true},
+		{map[string]string{"CLICOLOR_FORCE": "0"}, false, false},
+		{map[string]string{"FORCE_COLOR": "1"}, false, true},
+		{map[string]string{"FORCE_COLOR": "0"}, true, false},
+		{map[string]string{"FORCE_COLOR": "1", "CLICOLOR": "0"}, true, true},
+	}
+
+	for _, test := range tests {
+		getenv := func(key string) string { return test.env[key] }
+
+		assert.Equal(t, test.expected, detectEnabled(getenv, test.terminal), "env: %v, terminal: %v", test.env, test.terminal)
+	}
+}
+
+func TestRenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewProfileRenderer(ProfileANSI)
+
+	assert.True(r.Enabled())
+	assert.Equal(ProfileANSI, r.Profile())
+	assert.Equal("\x1b[1;31mfoo\x1b[0m", r.Sprint(New(Bold, FgRGB(200, 0, 0)), "foo"))
+	assert.Equal("\x1b[31mfoo\x1b[0m", r.StyleString("{red}foo{reset}"))
+
+	assert.Equal("\x1b[38;5;208mfoo\x1b[0m", NewProfileRenderer(ProfileANSI256).Sprint(FgHex(0xff8700), "foo"))
+
+	defer Disable()()
+	assert.False(r.Enabled())
+	assert.Equal("foo", r.StyleString("{red}foo{reset}"))
+}
+
+func TestStyle_Fprint_Renderer(t *testing.T) {
+	defer override(colorsAuto)()
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+
+	// A *bytes.Buffer is not a terminal.
+	New(Bold).Fprint(&buf, "foo")
+	assert.Equal("foo", buf.String())
+
+	defer Enable()()
+	buf.Reset()
+
+	New(Bold).Fprint(&buf, "foo")
+	assert.Equal("\x1b[1mfoo\x1b[0m", buf.String())
+}
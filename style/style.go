@@ -3,8 +3,8 @@ package style
 import (
 	"fmt"
 	"io"
-	"os"
 	"strings"
+	"sync/atomic"
 
 	colorable "github.com/mattn/go-colorable"
 	isatty "github.com/mattn/go-isatty"
@@ -16,37 +16,23 @@ var (
 	// Stdout is an io.Writer for stdout which properly handles escape
 	// sequences.
 	Stdout = colorable.NewColorableStdout()
-
-	// colorsEnabled controls whether the output is colorized or not. This is
-	// automatically set to true if the terminal is "dumb" or if stdout is not
-	// a TTY. Can be overridden to forcefully enable of disable colored output.
-	colorsEnabled = !(os.Getenv("TERM") == "dumb" || !isTerminal(os.Stdout.Fd()))
 )
 
-// Enabled returns true if colors are enabled.
-func Enabled() bool { return colorsEnabled }
-
-// Enable enables output coloring. The returned func can be used in combination
-// with defer to restore the previous state.
-func Enable() func() { return enable(true) }
+// Enabled returns true if colors are enabled for stdout.
+func Enabled() bool { return DefaultRenderer().Enabled() }
 
-// Disable disables output coloring. The returned func can be used in
-// combination with defer to restore the previous state.
-func Disable() func() { return enable(false) }
+// Enable forcefully enables output coloring for all renderers. The returned
+// func can be used in combination with defer to restore the previous state.
+func Enable() func() { return override(colorsForceEnabled) }
 
-func enable(enabled bool) func() {
-	oldColorsEnabled := colorsEnabled
-	colorsEnabled = enabled
+// Disable forcefully disables output coloring for all renderers. The returned
+// func can be used in combination with defer to restore the previous state.
+func Disable() func() { return override(colorsForceDisabled) }
 
-	if enabled != oldColorsEnabled {
-		// Clear sequence cache if colors were enabled or disabled.
-		sequenceCache.Range(func(key, value interface{}) bool {
-			sequenceCache.Delete(key)
-			return true
-		})
-	}
+func override(value int32) func() {
+	old := atomic.SwapInt32(&colorsOverride, value)
 
-	return func() { enable(oldColorsEnabled) }
+	return func() { atomic.StoreInt32(&colorsOverride, old) }
 }
 
 // Style can style and color text.
@@ -86,17 +72,12 @@ func BgRGB(r, g, b uint8) *Style {
 
 // FgHex creates a foreground RGB color attribute from a hex value.
 func FgHex(v uint32) *Style {
-	return FgRGB(toRGB(v))
+	return Hex(v).Fg()
 }
 
-// BgHex creates a foreground RGB color attribute from a hex value.
+// BgHex creates a background RGB color attribute from a hex value.
 func BgHex(v uint32) *Style {
-	return BgRGB(toRGB(v))
-}
-
-func toRGB(v uint32) (r uint8, g uint8, b uint8) {
-	r, g, b = uint8(v>>16), uint8(v>>8), uint8(v)
-	return
+	return Hex(v).Bg()
 }
 
 // NewWith creates a new *Style from s with additional attributes. Style s is
@@ -127,20 +108,71 @@ func (s *Style) add(attrs []Attribute) *Style {
 	return s
 }
 
-func (s *Style) sequence() string {
+// sequence implements Attribute. 256 and RGB colors are downsampled to the
+// colors supported by the color profile p.
+func (s *Style) sequence(p Profile) string {
 	var sb strings.Builder
 
-	for i, attr := range s.attrs {
-		if i != 0 {
+	for i := 0; i < len(s.attrs); i++ {
+		var seq string
+
+		if n := s.colorLen(i); n > 0 {
+			attr, mode := s.attrs[i].(SimpleAttribute), s.attrs[i+1].(SimpleAttribute)
+			values := make([]SimpleAttribute, n-2)
+
+			for j := range values {
+				values[j] = s.attrs[i+2+j].(SimpleAttribute)
+			}
+
+			seq = colorSequence(attr, mode, values, p)
+			i += n - 1
+		} else {
+			seq = s.attrs[i].sequence(p)
+		}
+
+		if seq == "" {
+			continue
+		}
+
+		if sb.Len() > 0 {
 			sb.WriteRune(';')
 		}
 
-		sb.WriteString(attr.sequence())
+		sb.WriteString(seq)
 	}
 
 	return sb.String()
 }
 
+// colorLen returns the number of attributes of the 256 or RGB color starting
+// at index i or 0 if there is none.
+func (s *Style) colorLen(i int) int {
+	if i+2 >= len(s.attrs) || (s.attrs[i] != FgColor && s.attrs[i] != BgColor) {
+		return 0
+	}
+
+	n := 0
+
+	switch s.attrs[i+1] {
+	case colorMode256:
+		n = 3
+	case colorModeRGB:
+		n = 5
+	}
+
+	if i+n > len(s.attrs) {
+		return 0
+	}
+
+	for _, attr := range s.attrs[i : i+n] {
+		if _, ok := attr.(SimpleAttribute); !ok {
+			return 0
+		}
+	}
+
+	return n
+}
+
 // Print formats using the default formats for its operands and writes to
 // standard output. Spaces are added between operands when neither is a string.
 // It returns the number of bytes written and any write error encountered.
@@ -216,7 +248,9 @@ func (s *Style) Sprintf(format string, args ...interface{}) string {
 }
 
 func (s *Style) wrapWriter(w io.Writer, fn func() (int, error)) (n int, err error) {
-	n, err = EscapeWriter(w, s)
+	r := RendererFor(w)
+
+	n, err = r.EscapeWriter(w, s)
 	if err != nil {
 		return
 	}
@@ -227,48 +261,43 @@ func (s *Style) wrapWriter(w io.Writer, fn func() (int, error)) (n int, err erro
 		return
 	}
 
-	nn, err = ResetWriter(w)
+	nn, err = r.ResetWriter(w)
 	n += nn
 	return
 }
 
 func (s *Style) wrapString(fn func() string) string {
-	return EscapeString(s) + fn() + ResetString()
+	r := DefaultRenderer()
+
+	return r.EscapeString(s) + fn() + r.ResetString()
 }
 
-// EscapeString creates the escape sequence for given attribute and returns it.
-// If coloring is disabled this returns an empty string.
+// EscapeString creates the escape sequence for given attribute using the
+// DefaultRenderer and returns it. See Renderer.EscapeString.
 func EscapeString(attr Attribute) string {
-	if !colorsEnabled {
-		return ""
-	}
-
-	return fmt.Sprintf("%s[%sm", escape, attr.sequence())
+	return DefaultRenderer().EscapeString(attr)
 }
 
 // EscapeWriter creates the escape sequence for given attribute and writes to
-// w. It returns the number of bytes written and any write error encountered.
-// If coloring is disabled this is a no-op.
+// w using the *Renderer for w. It returns the number of bytes written and any
+// write error encountered. If coloring is disabled for w this is a no-op.
 func EscapeWriter(w io.Writer, attr Attribute) (n int, err error) {
-	if !colorsEnabled {
-		return
-	}
-
-	return fmt.Fprintf(w, "%s[%sm", escape, attr.sequence())
+	return RendererFor(w).EscapeWriter(w, attr)
 }
 
 // ResetString creates the escape sequence for resetting all style attributes
-// and returns it.
-// If coloring is disabled this returns an empty string.
+// using the DefaultRenderer and returns it. If coloring is disabled this
+// returns an empty string.
 func ResetString() string {
-	return EscapeString(Reset)
+	return DefaultRenderer().ResetString()
 }
 
 // ResetWriter creates the escape sequence for resetting all style attributes
-// and writes to w. It returns the number of bytes written and any write error
-// encountered. If coloring is disabled this is a no-op.
+// and writes to w using the *Renderer for w. It returns the number of bytes
+// written and any write error encountered. If coloring is disabled for w this
+// is a no-op.
 func ResetWriter(w io.Writer) (n int, err error) {
-	return EscapeWriter(w, Reset)
+	return RendererFor(w).ResetWriter(w)
 }
 
 func isTerminal(fd uintptr) bool {
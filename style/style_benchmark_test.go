@@ -54,7 +54,7 @@ func BenchmarkAttributesSequence(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		a.sequence()
+		a.sequence(ProfileTrueColor)
 	}
 }
 
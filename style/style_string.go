@@ -2,7 +2,6 @@ package style
 
 import (
 	"strings"
-	"sync"
 )
 
 const (
@@ -11,27 +10,27 @@ const (
 	attrBlockEnd   = '}'
 )
 
-var (
-	// sequenceCache is a map of raw style attribute names such as
-	// "yellow,bold" to the resulting escape sequencesafe for concurrent use.
-	// This is used to reduce the amount of heavy lifting during style
-	// replacements in strings.
-	sequenceCache sync.Map
-)
+// sequenceKey is the key of a sequence in the sequence cache of the active
+// theme.
+type sequenceKey struct {
+	profile Profile
+	raw     string
+}
 
 // StyleString replaces all supported style attributes of the from "{attr1,attr2}"
 // in s with the corresponding ANSI escape sequences. If an attribute is not
 // recognized it is not replaced. If styles are disabled, style attributes are
-// replaced with empty strings. This supports all attribute names defined in
-// AttributeMap.
+// replaced with empty strings. This supports the names of the active theme
+// and all attribute names defined in AttributeMap. Theme names take
+// precedence. The escape sequences are created by the DefaultRenderer.
 func StyleString(s string) string {
-	return styleString(s)
+	return styleString(DefaultRenderer(), s)
 }
 
 // styleString replaces style attribute blocks of the form
 // `{attr1[,attr2]*}` with style escape sequences. This avoids the usage of
 // regular expressions for performance reasons.
-func styleString(s string) string {
+func styleString(r *Renderer, s string) string {
 	// // Fast path
 	if len(s) <= 2 || !strings.ContainsRune(s, attrBlockStart) {
 		return s
@@ -93,7 +92,7 @@ func styleString(s string) string {
 
 		// Write out the ANSI escape sequence if it could be built or just
 		// append the original attribute block unaltered.
-		sequence, ok := resolveEscapeSequence(rawBlock)
+		sequence, ok := resolveEscapeSequence(r, rawBlock)
 		if ok {
 			sb.WriteString(sequence)
 			continue
@@ -107,30 +106,52 @@ func styleString(s string) string {
 	return sb.String()
 }
 
-func resolveEscapeSequence(raw string) (string, bool) {
-	raw = strings.ToLower(raw)
+func resolveEscapeSequence(r *Renderer, raw string) (string, bool) {
+	active := currentTheme.Load().(*activeTheme)
+	key := sequenceKey{r.Profile(), raw}
+
+	val, ok := active.sequences.Load(key)
+	if !ok {
+		sequence, valid := buildEscapeSequence(raw, active.theme, key.profile)
+		if !valid {
+			return raw, false
+		}
 
-	val, ok := sequenceCache.Load(raw)
-	if ok {
-		return val.(string), true
+		val, _ = active.sequences.LoadOrStore(key, sequence)
 	}
 
+	if !r.Enabled() {
+		return "", true
+	}
+
+	return val.(string), true
+}
+
+// buildEscapeSequence builds the escape sequence for the comma separated
+// theme and attribute names in raw using color profile p. Theme names are
+// case-sensitive, attribute names are not. Returns false if any of the names
+// is unknown.
+func buildEscapeSequence(raw string, theme Theme, p Profile) (string, bool) {
 	attrNames := strings.Split(raw, ",")
 
 	attrs := make([]Attribute, 0, len(attrNames))
 
 	for _, name := range attrNames {
-		attr, ok := AttributeMap[name]
+		if s, ok := theme[name]; ok {
+			if s != nil {
+				attrs = append(attrs, s)
+			}
+
+			continue
+		}
+
+		attr, ok := AttributeMap[strings.ToLower(name)]
 		if !ok {
-			return raw, false
+			return "", false
 		}
 
 		attrs = append(attrs, attr)
 	}
 
-	sequence := EscapeString(&Style{attrs})
-
-	sequenceCache.Store(raw, sequence)
-
-	return sequence, true
+	return escapeSequence(&Style{attrs}, p), true
 }
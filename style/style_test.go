@@ -9,6 +9,7 @@ import (
 
 func TestStyle_Print(t *testing.T) {
 	defer Enable()()
+	defer SetColorProfile(ProfileTrueColor)()
 	assert := assert.New(t)
 
 	assert.Equal("\x1b[1mfoo\x1b[0m", New(Bold).Sprint("foo"))
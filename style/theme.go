@@ -0,0 +1,127 @@
+package style
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// Theme maps semantic names like "error" or "bar.complete" to styles. The
+// names of the active theme can be used in StyleString markup, e.g.
+// "{error}failed{reset}", and components like tables and progress bars take
+// their default styles from it. A nil style means that no style is applied.
+//
+// Themes can be loaded from JSON objects mapping names to style specs in the
+// format understood by Parse:
+//
+//	{"error": "bold red", "bar.complete": "#ff8800"}
+//
+// Names are case-sensitive. The following names are used by the components of this module:
+//
+//	bar.remaining, bar.complete, bar.finished
+//	progress.description, progress.progress, progress.eta
+//	table.border, table.title, table.caption, table.header, table.footer
+type Theme map[string]*Style
+
+// DefaultTheme is the theme that is active unless SetTheme is called.
+var DefaultTheme = Theme{
+	"error":   New(FgRed),
+	"warning": New(FgYellow),
+	"success": New(FgGreen),
+	"info":    New(FgCyan),
+	"muted":   New(Faint),
+	"header":  New(Bold),
+
+	"bar.remaining": New(FgBlack),
+	"bar.complete":  New(FgRed),
+	"bar.finished":  New(FgGreen),
+
+	"progress.description": New(Bold),
+	"progress.progress":    New(FgCyan),
+	"progress.eta":         New(FgGreen),
+}
+
+// activeTheme is the theme returned by CurrentTheme.
+type activeTheme struct {
+	theme Theme
+	// sequences is a map of sequenceKey to the escape sequences that were
+	// built for raw style attribute names such as "yellow,bold" using theme.
+	// This is used to reduce the amount of heavy lifting during style
+	// replacements in strings. Since raw names are resolved through the
+	// theme, the cache is replaced together with it.
+	sequences *sync.Map
+}
+
+var currentTheme atomic.Value
+
+func init() {
+	SetTheme(DefaultTheme)
+}
+
+// CurrentTheme returns the active theme.
+func CurrentTheme() Theme {
+	return currentTheme.Load().(*activeTheme).theme
+}
+
+// SetTheme makes t the active theme. The returned func can be used in
+// combination with defer to restore the previous theme.
+func SetTheme(t Theme) func() {
+	var old Theme
+	if active, ok := currentTheme.Load().(*activeTheme); ok {
+		old = active.theme
+	}
+
+	currentTheme.Store(&activeTheme{theme: t, sequences: &sync.Map{}})
+
+	return func() { SetTheme(old) }
+}
+
+// ThemeStyle returns the style of the active theme for name. Returns nil if
+// the theme does not contain name.
+func ThemeStyle(name string) *Style {
+	return CurrentTheme()[name]
+}
+
+// Merge returns a new Theme containing the styles of t and other. Styles of
+// other take precedence.
+func (t Theme) Merge(other Theme) Theme {
+	merged := make(Theme, len(t)+len(other))
+
+	for name, s := range t {
+		merged[name] = s
+	}
+
+	for name, s := range other {
+		merged[name] = s
+	}
+
+	return merged
+}
+
+// LoadTheme reads a theme from a JSON object mapping names to style specs.
+// The loaded styles are merged with DefaultTheme, so that themes only need to
+// contain the names they want to change. A name can be mapped to null to
+// remove its style. Returns an error if the JSON is malformed or contains
+// invalid style specs.
+func LoadTheme(r io.Reader) (Theme, error) {
+	var t Theme
+
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, err
+	}
+
+	return DefaultTheme.Merge(t), nil
+}
+
+// LoadThemeFile reads a theme from the JSON file at path. See LoadTheme.
+func LoadThemeFile(path string) (Theme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadTheme(f)
+}
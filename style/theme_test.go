@@ -0,0 +1,109 @@
+package style
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleString_Theme(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewProfileRenderer(ProfileANSI)
+
+	assert.Equal("\x1b[31mfailed\x1b[0m", r.StyleString("{error}failed{reset}"))
+	assert.Equal("\x1b[1;31mfailed\x1b[0m", r.StyleString("{bold,error}failed{reset}"))
+
+	defer SetTheme(DefaultTheme.Merge(Theme{
+		"error": New(Bold, FgMagenta),
+		"red":   New(FgBlue),
+		"plain": nil,
+	}))()
+
+	assert.Equal("\x1b[1;35mfailed\x1b[0m", r.StyleString("{error}failed{reset}"))
+	assert.Equal("\x1b[34mblue\x1b[0m", r.StyleString("{red}blue{reset}"))
+	assert.Equal("plain\x1b[0m", r.StyleString("{plain}plain{reset}"))
+	assert.Equal("{unknown}foo", r.StyleString("{unknown}foo"))
+}
+
+func TestStyleString_ThemeCase(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewProfileRenderer(ProfileANSI)
+
+	defer SetTheme(Theme{"Warning": New(FgYellow)})()
+
+	assert.Equal("\x1b[33mwarn\x1b[0m", r.StyleString("{Warning}warn{RESET}"))
+	assert.Equal("{warning}warn", r.StyleString("{warning}warn"))
+}
+
+func TestSetTheme_ResetsSequenceCache(t *testing.T) {
+	assert := assert.New(t)
+
+	cached := func() (n int) {
+		currentTheme.Load().(*activeTheme).sequences.Range(func(_, _ interface{}) bool {
+			n++
+			return true
+		})
+		return n
+	}
+
+	r := NewProfileRenderer(ProfileANSI)
+
+	defer SetTheme(CurrentTheme())()
+
+	r.StyleString("{error}failed{reset}")
+	assert.Equal(2, cached())
+
+	SetTheme(CurrentTheme())
+	assert.Equal(0, cached())
+}
+
+func TestSetTheme(t *testing.T) {
+	assert := assert.New(t)
+
+	restore := SetTheme(Theme{"error": New(FgYellow)})
+
+	assert.Equal(New(FgYellow), ThemeStyle("error"))
+	assert.Nil(ThemeStyle("warning"))
+
+	restore()
+
+	assert.Equal(New(FgRed), ThemeStyle("error"))
+}
+
+func TestTheme_Merge(t *testing.T) {
+	a := Theme{"error": New(FgRed), "muted": New(Faint)}
+	b := Theme{"error": New(FgYellow), "header": nil}
+
+	assert.Equal(t, Theme{
+		"error":  New(FgYellow),
+		"muted":  New(Faint),
+		"header": nil,
+	}, a.Merge(b))
+	assert.Equal(t, New(FgRed), a["error"])
+}
+
+func TestLoadTheme(t *testing.T) {
+	assert := assert.New(t)
+
+	theme, err := LoadTheme(strings.NewReader(`{"error": "bold red", "bar.complete": "#ff8800", "muted": null}`))
+	assert.NoError(err)
+
+	r := NewProfileRenderer(ProfileTrueColor)
+
+	assert.Equal("\x1b[1;31mx\x1b[0m", r.Sprint(theme["error"], "x"))
+	assert.Equal("\x1b[38;2;255;136;0mx\x1b[0m", r.Sprint(theme["bar.complete"], "x"))
+	assert.Nil(theme["muted"])
+	assert.Equal(DefaultTheme["success"], theme["success"])
+
+	_, err = LoadTheme(strings.NewReader(`{"error": "bold reddish"}`))
+
+	var perr *ParseError
+	assert.True(errors.As(err, &perr))
+
+	_, err = LoadTheme(strings.NewReader(`{"error": `))
+	assert.Error(err)
+}
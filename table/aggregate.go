@@ -0,0 +1,171 @@
+package table
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// numberRegexp matches the first number in a string, optionally containing
+// thousands separators, e.g. "1,024.5" in "1,024.5 MB/s".
+var numberRegexp = regexp.MustCompile(`[-+]?\d[\d,]*(\.\d+)?`)
+
+// AggregateFunc reduces the numeric values of a table column to a single
+// value.
+type AggregateFunc func(values []float64) float64
+
+// Aggregate configures how the value of an automatically generated footer
+// cell is computed from the values of the normal rows of a column. See
+// WithColumnAggregates.
+type Aggregate struct {
+	// Func computes the footer value from all numeric values of the column.
+	// Cells whose text does not contain a number are skipped. If there are no
+	// numeric values at all, the footer cell is left empty. If Func is nil,
+	// Label is used as footer value instead.
+	Func AggregateFunc
+	// Format formats the result of Func. If nil, the result is formatted
+	// using the smallest number of digits necessary to represent it.
+	Format func(float64) string
+	// Label is the footer value if Func is nil, e.g. "Total".
+	Label string
+}
+
+// Sum returns the sum of values.
+func Sum(values []float64) (sum float64) {
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum
+}
+
+// Avg returns the arithmetic mean of values.
+func Avg(values []float64) float64 {
+	return Sum(values) / float64(len(values))
+}
+
+// Min returns the smallest of values.
+func Min(values []float64) float64 {
+	min := math.Inf(1)
+
+	for _, v := range values {
+		min = math.Min(min, v)
+	}
+
+	return min
+}
+
+// Max returns the biggest of values.
+func Max(values []float64) float64 {
+	max := math.Inf(-1)
+
+	for _, v := range values {
+		max = math.Max(max, v)
+	}
+
+	return max
+}
+
+// Count returns the number of values.
+func Count(values []float64) float64 {
+	return float64(len(values))
+}
+
+// parseNumber extracts the first number from the plain text of v. Thousands
+// separators are ignored, e.g. "1,024.5 MB/s" yields 1024.5. Returns false if
+// v does not contain a number.
+func parseNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+
+	match := numberRegexp.FindString(valueText(v))
+	if match == "" {
+		return 0, false
+	}
+
+	f, err := strconv.ParseFloat(strings.ReplaceAll(match, ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return f, true
+}
+
+// aggregateRow computes the footer row from the configured column aggregates
+// and the values of the normal rows in rows.
+func (t *Table) aggregateRow(rows []*tableRow) *tableRow {
+	values := make([][]float64, t.numCols)
+
+	for _, row := range rows {
+		if row.kind != rowKindNormal {
+			continue
+		}
+
+		for i, cell := range row.cells {
+			if cell == nil {
+				continue
+			}
+
+			if f, ok := parseNumber(cell.value); ok {
+				values[i] = append(values[i], f)
+			}
+		}
+	}
+
+	cells := make([]*tableCell, t.numCols)
+
+	for i := range cells {
+		var value string
+
+		if i < len(t.aggregates) && t.aggregates[i] != nil {
+			value = t.aggregates[i].value(values[i])
+		}
+
+		cells[i] = t.makeCell(value, i)
+	}
+
+	return &tableRow{kind: rowKindFooter, cells: cells}
+}
+
+func (a *Aggregate) value(values []float64) string {
+	if a.Func == nil {
+		return a.Label
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	result := a.Func(values)
+
+	if a.Format != nil {
+		return a.Format(result)
+	}
+
+	return strconv.FormatFloat(result, 'f', -1, 64)
+}
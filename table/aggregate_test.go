@@ -0,0 +1,77 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	parse := func(v interface{}) interface{} {
+		f, ok := parseNumber(v)
+		if !ok {
+			return nil
+		}
+		return f
+	}
+
+	assert.Equal(42.0, parse(42))
+	assert.Equal(1.5, parse(float32(1.5)))
+	assert.Equal(1024.5, parse("1,024.5 MB/s"))
+	assert.Equal(-3.0, parse("delta: -3"))
+	assert.Equal(45.0, parse("\x1b[31m45%\x1b[0m"))
+	assert.Nil(parse("n/a"))
+	assert.Nil(parse(nil))
+}
+
+func TestTable_Render_Aggregates(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := New(&buf,
+		WithBorderMask(BorderSection),
+		WithColumnAggregates(
+			&Aggregate{Label: "total"},
+			&Aggregate{Func: Sum},
+			&Aggregate{Func: Avg, Format: func(f float64) string { return fmt.Sprintf("%.2f", f) }},
+			&Aggregate{Func: Max},
+			nil,
+		),
+		WithRowFilter(func(values []interface{}) bool {
+			return values[0] != "skip"
+		})).
+		AddHeader("name", "size", "load", "max", "status").
+		AddRow("foo", "10 MB", 0.5, 3, "ok").
+		AddRow("bar", "1,000 MB", "n/a", 7, "ok").
+		AddRow("baz", "5 MB", 1, -1, "failed").
+		AddRow("skip", "1 MB", 100, 100, "ok").
+		AddFooter("", "", "", "", "footer").
+		Render()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `name  size     load max status
+══════════════════════════════
+foo   10 MB    0.5  3   ok    
+bar   1,000 MB n/a  7   ok    
+baz   5 MB     1    -1  failed
+══════════════════════════════
+total 1015     0.75 7         
+══════════════════════════════
+                        footer
+`, buf.String())
+}
+
+func TestTable_Render_AggregatesOverlongRowSpan(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := New(&buf, WithBorderMask(BorderSection), WithColumnAggregates(&Aggregate{Func: Sum}, &Aggregate{Func: Sum})).
+		AddRow(1, 10).
+		AddRow(2, Cell{Value: "20\n30", RowSpan: 2}).
+		Render()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1 10\n2 20\n  30\n════\n3 30\n", buf.String())
+}
@@ -18,6 +18,9 @@ const (
 	BorderRow
 	BorderBottom
 	BorderSection
+	// BorderHeader only draws the section border below the header rows.
+	// It is implied by BorderSection.
+	BorderHeader
 
 	BorderAllHorizontal = BorderTop | BorderRow | BorderBottom | BorderSection
 	BorderAllVertical   = BorderLeft | BorderColumn | BorderRight
@@ -37,7 +40,7 @@ const (
 	BorderRuneCornerTopLeft                               // ┌
 	BorderRuneCornerTopRight                              // ┐
 	BorderRuneCornerBottomLeft                            // └
-	BorderRuneCornerBottomRight                           // └
+	BorderRuneCornerBottomRight                           // ┘
 	BorderRuneIntersectionTop                             // ┬
 	BorderRuneIntersectionBottom                          // ┴
 	BorderRuneIntersectionLeft                            // ├
@@ -47,6 +50,8 @@ const (
 	BorderRuneSectionIntersectionLeft                     // ╞
 	BorderRuneSectionIntersectionRight                    // ╡
 	BorderRuneSectionIntersectionCenter                   // ╪
+	BorderRuneSectionIntersectionTop                      // ╤
+	BorderRuneSectionIntersectionBottom                   // ╧
 )
 
 // BorderRunes is a map of the BorderRune type to the actual rune that should
@@ -72,4 +77,16 @@ var DefaultBorderRunes = BorderRunes{
 	BorderRuneSectionIntersectionLeft:   '╞',
 	BorderRuneSectionIntersectionRight:  '╡',
 	BorderRuneSectionIntersectionCenter: '╪',
+	BorderRuneSectionIntersectionTop:    '╤',
+	BorderRuneSectionIntersectionBottom: '╧',
+}
+
+// copy returns a copy of r.
+func (r BorderRunes) copy() BorderRunes {
+	runes := make(BorderRunes, len(r))
+	for k, v := range r {
+		runes[k] = v
+	}
+
+	return runes
 }
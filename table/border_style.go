@@ -0,0 +1,256 @@
+package table
+
+import "strings"
+
+// BorderStyle is a named preset of border runes together with a matching
+// border mask. Use WithBorder to apply it to a table.
+type BorderStyle struct {
+	// Name is the name of the border style that can be passed to
+	// BorderStyleByName.
+	Name string
+	// Runes are the runes used to draw the borders.
+	Runes BorderRunes
+	// Mask controls which borders are displayed.
+	Mask BorderMask
+}
+
+// defaultBorderMask is the border mask of most border style presets: all
+// borders except the horizontal lines between normal rows.
+const defaultBorderMask = BorderAll &^ BorderRow
+
+// Border style presets.
+var (
+	// BorderStyleNone does not display any borders.
+	BorderStyleNone = BorderStyle{
+		Name:  "none",
+		Runes: DefaultBorderRunes.copy(),
+		Mask:  BorderNone,
+	}
+
+	// BorderStyleSingle uses light box-drawing runes.
+	//
+	//	┌─────┬─────┐
+	//	│ foo │ bar │
+	//	╞═════╪═════╡
+	//	│ baz │ qux │
+	//	└─────┴─────┘
+	BorderStyleSingle = BorderStyle{
+		Name:  "single",
+		Runes: DefaultBorderRunes.copy(),
+		Mask:  defaultBorderMask,
+	}
+
+	// BorderStyleASCII only uses ASCII characters.
+	//
+	//	+-----+-----+
+	//	| foo | bar |
+	//	+=====+=====+
+	//	| baz | qux |
+	//	+-----+-----+
+	BorderStyleASCII = BorderStyle{
+		Name: "ascii",
+		Runes: BorderRunes{
+			BorderRuneHorizontal:                '-',
+			BorderRuneVertical:                  '|',
+			BorderRuneCornerTopLeft:             '+',
+			BorderRuneCornerTopRight:            '+',
+			BorderRuneCornerBottomLeft:          '+',
+			BorderRuneCornerBottomRight:         '+',
+			BorderRuneIntersectionTop:           '+',
+			BorderRuneIntersectionBottom:        '+',
+			BorderRuneIntersectionLeft:          '+',
+			BorderRuneIntersectionRight:         '+',
+			BorderRuneIntersectionCenter:        '+',
+			BorderRuneSectionHorizontal:         '=',
+			BorderRuneSectionIntersectionLeft:   '+',
+			BorderRuneSectionIntersectionRight:  '+',
+			BorderRuneSectionIntersectionCenter: '+',
+			BorderRuneSectionIntersectionTop:    '+',
+			BorderRuneSectionIntersectionBottom: '+',
+		},
+		Mask: defaultBorderMask,
+	}
+
+	// BorderStyleRounded uses light box-drawing runes with rounded corners.
+	//
+	//	╭─────┬─────╮
+	//	│ foo │ bar │
+	//	╞═════╪═════╡
+	//	│ baz │ qux │
+	//	╰─────┴─────╯
+	BorderStyleRounded = BorderStyle{
+		Name: "rounded",
+		Runes: BorderRunes{
+			BorderRuneHorizontal:                '─',
+			BorderRuneVertical:                  '│',
+			BorderRuneCornerTopLeft:             '╭',
+			BorderRuneCornerTopRight:            '╮',
+			BorderRuneCornerBottomLeft:          '╰',
+			BorderRuneCornerBottomRight:         '╯',
+			BorderRuneIntersectionTop:           '┬',
+			BorderRuneIntersectionBottom:        '┴',
+			BorderRuneIntersectionLeft:          '├',
+			BorderRuneIntersectionRight:         '┤',
+			BorderRuneIntersectionCenter:        '┼',
+			BorderRuneSectionHorizontal:         '═',
+			BorderRuneSectionIntersectionLeft:   '╞',
+			BorderRuneSectionIntersectionRight:  '╡',
+			BorderRuneSectionIntersectionCenter: '╪',
+			BorderRuneSectionIntersectionTop:    '╤',
+			BorderRuneSectionIntersectionBottom: '╧',
+		},
+		Mask: defaultBorderMask,
+	}
+
+	// BorderStyleDouble uses double box-drawing runes.
+	//
+	//	╔═════╦═════╗
+	//	║ foo ║ bar ║
+	//	╠═════╬═════╣
+	//	║ baz ║ qux ║
+	//	╚═════╩═════╝
+	BorderStyleDouble = BorderStyle{
+		Name: "double",
+		Runes: BorderRunes{
+			BorderRuneHorizontal:                '═',
+			BorderRuneVertical:                  '║',
+			BorderRuneCornerTopLeft:             '╔',
+			BorderRuneCornerTopRight:            '╗',
+			BorderRuneCornerBottomLeft:          '╚',
+			BorderRuneCornerBottomRight:         '╝',
+			BorderRuneIntersectionTop:           '╦',
+			BorderRuneIntersectionBottom:        '╩',
+			BorderRuneIntersectionLeft:          '╠',
+			BorderRuneIntersectionRight:         '╣',
+			BorderRuneIntersectionCenter:        '╬',
+			BorderRuneSectionHorizontal:         '═',
+			BorderRuneSectionIntersectionLeft:   '╠',
+			BorderRuneSectionIntersectionRight:  '╣',
+			BorderRuneSectionIntersectionCenter: '╬',
+			BorderRuneSectionIntersectionTop:    '╦',
+			BorderRuneSectionIntersectionBottom: '╩',
+		},
+		Mask: defaultBorderMask,
+	}
+
+	// BorderStyleHeavy uses heavy box-drawing runes.
+	//
+	//	┏━━━━━┳━━━━━┓
+	//	┃ foo ┃ bar ┃
+	//	┣━━━━━╋━━━━━┫
+	//	┃ baz ┃ qux ┃
+	//	┗━━━━━┻━━━━━┛
+	BorderStyleHeavy = BorderStyle{
+		Name: "heavy",
+		Runes: BorderRunes{
+			BorderRuneHorizontal:                '━',
+			BorderRuneVertical:                  '┃',
+			BorderRuneCornerTopLeft:             '┏',
+			BorderRuneCornerTopRight:            '┓',
+			BorderRuneCornerBottomLeft:          '┗',
+			BorderRuneCornerBottomRight:         '┛',
+			BorderRuneIntersectionTop:           '┳',
+			BorderRuneIntersectionBottom:        '┻',
+			BorderRuneIntersectionLeft:          '┣',
+			BorderRuneIntersectionRight:         '┫',
+			BorderRuneIntersectionCenter:        '╋',
+			BorderRuneSectionHorizontal:         '━',
+			BorderRuneSectionIntersectionLeft:   '┣',
+			BorderRuneSectionIntersectionRight:  '┫',
+			BorderRuneSectionIntersectionCenter: '╋',
+			BorderRuneSectionIntersectionTop:    '┳',
+			BorderRuneSectionIntersectionBottom: '┻',
+		},
+		Mask: defaultBorderMask,
+	}
+
+	// BorderStyleDashed uses dashed light box-drawing runes for horizontal
+	// and vertical lines.
+	//
+	//	┌┄┄┄┄┄┬┄┄┄┄┄┐
+	//	┆ foo ┆ bar ┆
+	//	╞═════╪═════╡
+	//	┆ baz ┆ qux ┆
+	//	└┄┄┄┄┄┴┄┄┄┄┄┘
+	BorderStyleDashed = BorderStyle{
+		Name: "dashed",
+		Runes: BorderRunes{
+			BorderRuneHorizontal:                '┄',
+			BorderRuneVertical:                  '┆',
+			BorderRuneCornerTopLeft:             '┌',
+			BorderRuneCornerTopRight:            '┐',
+			BorderRuneCornerBottomLeft:          '└',
+			BorderRuneCornerBottomRight:         '┘',
+			BorderRuneIntersectionTop:           '┬',
+			BorderRuneIntersectionBottom:        '┴',
+			BorderRuneIntersectionLeft:          '├',
+			BorderRuneIntersectionRight:         '┤',
+			BorderRuneIntersectionCenter:        '┼',
+			BorderRuneSectionHorizontal:         '═',
+			BorderRuneSectionIntersectionLeft:   '╞',
+			BorderRuneSectionIntersectionRight:  '╡',
+			BorderRuneSectionIntersectionCenter: '╪',
+			BorderRuneSectionIntersectionTop:    '╤',
+			BorderRuneSectionIntersectionBottom: '╧',
+		},
+		Mask: defaultBorderMask,
+	}
+
+	// BorderStyleMarkdown renders the table as Markdown pipe table. Note
+	// that Markdown tables only support a single header row and cannot
+	// span multiple columns or rows. Footer rows are rendered like normal
+	// rows since Markdown tables do not have a footer section. Use the
+	// MarkdownExporter for proper Markdown output.
+	//
+	//	| foo | bar |
+	//	|-----|-----|
+	//	| baz | qux |
+	BorderStyleMarkdown = BorderStyle{
+		Name: "markdown",
+		Runes: BorderRunes{
+			BorderRuneHorizontal:                '-',
+			BorderRuneVertical:                  '|',
+			BorderRuneCornerTopLeft:             '|',
+			BorderRuneCornerTopRight:            '|',
+			BorderRuneCornerBottomLeft:          '|',
+			BorderRuneCornerBottomRight:         '|',
+			BorderRuneIntersectionTop:           '|',
+			BorderRuneIntersectionBottom:        '|',
+			BorderRuneIntersectionLeft:          '|',
+			BorderRuneIntersectionRight:         '|',
+			BorderRuneIntersectionCenter:        '|',
+			BorderRuneSectionHorizontal:         '-',
+			BorderRuneSectionIntersectionLeft:   '|',
+			BorderRuneSectionIntersectionRight:  '|',
+			BorderRuneSectionIntersectionCenter: '|',
+			BorderRuneSectionIntersectionTop:    '|',
+			BorderRuneSectionIntersectionBottom: '|',
+		},
+		Mask: BorderAllVertical | BorderHeader,
+	}
+)
+
+// BorderStyles contains all border style presets.
+var BorderStyles = []BorderStyle{
+	BorderStyleNone,
+	BorderStyleSingle,
+	BorderStyleASCII,
+	BorderStyleRounded,
+	BorderStyleDouble,
+	BorderStyleHeavy,
+	BorderStyleDashed,
+	BorderStyleMarkdown,
+}
+
+// BorderStyleByName looks up the border style preset with name. The lookup
+// is case insensitive. Returns an *UnknownBorderStyleError if there is no
+// preset with that name.
+func BorderStyleByName(name string) (BorderStyle, error) {
+	for _, bs := range BorderStyles {
+		if strings.EqualFold(bs.Name, name) {
+			return bs, nil
+		}
+	}
+
+	return BorderStyle{}, &UnknownBorderStyleError{Name: name}
+}
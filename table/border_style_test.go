@@ -0,0 +1,89 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBorderStyleByName(t *testing.T) {
+	bs, err := BorderStyleByName("Rounded")
+	assert.NoError(t, err)
+	assert.Equal(t, "rounded", bs.Name)
+
+	_, err = BorderStyleByName("fancy")
+	assert.Equal(t, &UnknownBorderStyleError{Name: "fancy"}, err)
+	assert.EqualError(t, err, `unknown border style "fancy"`)
+}
+
+func TestTable_Render_BorderStyle(t *testing.T) {
+	tests := []struct {
+		bs       BorderStyle
+		expected string
+	}{
+		{
+			bs:       BorderStyleRounded,
+			expected: "╭─────┬─────╮\n│ foo │ bar │\n╞═════╪═════╡\n│ baz │ qux │\n╰─────┴─────╯\n",
+		},
+		{
+			bs:       BorderStyleASCII,
+			expected: "+-----+-----+\n| foo | bar |\n+=====+=====+\n| baz | qux |\n+-----+-----+\n",
+		},
+		{
+			bs:       BorderStyleMarkdown,
+			expected: "| foo | bar |\n|-----|-----|\n| baz | qux |\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.bs.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := New(&buf, WithBorder(test.bs)).
+				AddHeader("foo", "bar").
+				AddRow("baz", "qux").
+				Render()
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestWithBorder_CopiesRunes(t *testing.T) {
+	bs := BorderStyle{Runes: BorderRunes{BorderRuneVertical: '!'}}
+
+	New(&bytes.Buffer{}, WithBorder(bs))
+
+	// Missing runes are filled into the table's copy only.
+	assert.Len(t, bs.Runes, 1)
+}
+
+func TestBorderStyleMarkdown_Footer(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := New(&buf, WithBorder(BorderStyleMarkdown), WithSortKeys(SortKey{Column: 0}), WithGroupBy(0)).
+		AddHeader("foo", "bar").
+		AddRow("b", "qux").
+		AddRow("a", "baz").
+		AddFooter("sum", "2").
+		Render()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "| foo | bar |\n|-----|-----|\n| a   | baz |\n| b   | qux |\n| sum | 2   |\n", buf.String())
+}
+
+func TestBorderStyles_OwnRunes(t *testing.T) {
+	runes := BorderRunes{BorderRuneVertical: '!'}
+
+	New(&bytes.Buffer{}, WithBorderRunes(runes))
+
+	assert.Len(t, runes, 1)
+
+	BorderStyleSingle.Runes[BorderRuneVertical] = '!'
+	defer func() { BorderStyleSingle.Runes[BorderRuneVertical] = '│' }()
+
+	assert.Equal(t, '│', DefaultBorderRunes[BorderRuneVertical])
+	assert.Equal(t, '│', BorderStyleNone.Runes[BorderRuneVertical])
+}
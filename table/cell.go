@@ -0,0 +1,117 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/martinohmann/neat/console"
+	"github.com/martinohmann/neat/internal/util"
+	"github.com/martinohmann/neat/measure"
+	"github.com/martinohmann/neat/style"
+	"github.com/martinohmann/neat/text"
+)
+
+// Cell is a table cell that can span multiple columns and rows and override
+// the column and table defaults for alignment, style and word wrapping. It
+// can be passed to AddRow, AddHeader and AddFooter in place of a plain value.
+//
+//	t.AddRow("foo", table.Cell{
+//		Value:     "bar",
+//		Alignment: table.Align(text.AlignRight),
+//		Style:     style.New(style.FgRed),
+//	})
+//
+// Grid slots covered by a cell spanning multiple rows are skipped when adding
+// subsequent rows, that is, the values of these rows are placed into the
+// remaining free slots from left to right.
+type Cell struct {
+	// Value is the content of the cell. It is handled exactly like values
+	// that are directly passed to AddRow.
+	Value interface{}
+	// ColSpan is the number of columns the cell spans. Values < 1 are
+	// treated as 1.
+	ColSpan int
+	// RowSpan is the number of rows the cell spans. Values < 1 are treated
+	// as 1. Row spans exceeding the last table row are cut off. Row spans
+	// also end with the last row of their kind, e.g. a cell of a header row
+	// never spans into the normal rows below it.
+	RowSpan int
+	// Alignment overrides the alignment of the column if non-nil. Has no
+	// effect if Value implements console.Renderable.
+	Alignment *text.Alignment
+	// Style overrides the style of the column if non-nil. Has no effect if
+	// Value implements console.Renderable.
+	Style *style.Style
+	// WordWrap overrides the word wrapping behaviour of the column if
+	// non-nil. Has no effect if Value implements console.Renderable.
+	WordWrap *bool
+	// Padding is the number of spaces that are inserted left and right of
+	// the cell content in addition to the table padding.
+	Padding int
+}
+
+// Align returns a pointer to alignment. It is a helper for setting
+// Cell.Alignment.
+func Align(alignment text.Alignment) *text.Alignment {
+	return &alignment
+}
+
+// Wrap returns a pointer to wordWrap. It is a helper for setting
+// Cell.WordWrap.
+func Wrap(wordWrap bool) *bool {
+	return &wordWrap
+}
+
+// tableCell is a renderable table cell together with its span information.
+type tableCell struct {
+	console.Renderable
+
+	// value is the original value that was used to create the cell.
+	value   interface{}
+	colSpan int
+	rowSpan int
+}
+
+func newTableCell(r console.Renderable, value interface{}, colSpan, rowSpan int) *tableCell {
+	if colSpan < 1 {
+		colSpan = 1
+	}
+
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+
+	return &tableCell{
+		Renderable: r,
+		value:      value,
+		colSpan:    colSpan,
+		rowSpan:    rowSpan,
+	}
+}
+
+// paddedRenderable is a console.Renderable that surrounds the lines of the
+// wrapped console.Renderable with spaces.
+type paddedRenderable struct {
+	console.Renderable
+
+	padding int
+}
+
+// Measure implements console.Renderable.
+func (r paddedRenderable) Measure(maxWidth int) measure.Measurement {
+	m := r.Renderable.Measure(util.MaxInt(0, maxWidth-2*r.padding))
+
+	return measure.NewMeasurement(m.Minimum+2*r.padding, m.Maximum+2*r.padding)
+}
+
+// Render implements console.Renderable.
+func (r paddedRenderable) Render(width int) string {
+	padding := util.MinInt(r.padding, width/2)
+	spaces := text.Spaces(padding)
+
+	lines := text.SplitLines(r.Renderable.Render(width - 2*padding))
+	for i, line := range lines {
+		lines[i] = spaces + line + spaces
+	}
+
+	return strings.Join(lines, "\n")
+}
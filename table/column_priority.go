@@ -0,0 +1,148 @@
+package table
+
+import (
+	"github.com/martinohmann/neat/measure"
+	"github.com/martinohmann/neat/text"
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// columnPriority returns the priority of the column at colIdx.
+func (t *Table) columnPriority(colIdx int) int {
+	if colIdx < len(t.columnPriorities) {
+		return t.columnPriorities[colIdx]
+	}
+
+	return 0
+}
+
+// hideColumns hides columns with the lowest priority until the minimum width
+// of the remaining columns fits into the maximum table width or there is only
+// one column left. Columns are only hidden if column priorities are
+// configured. Returns the *Table to render the returned rows with. If columns
+// were hidden, this is a copy of t with the per-column options adjusted to the
+// remaining columns.
+func (t *Table) hideColumns(rows []*tableRow) (*Table, []*tableRow) {
+	if len(t.columnPriorities) == 0 {
+		return t, rows
+	}
+
+	visible := make([]int, t.numCols)
+	for i := range visible {
+		visible[i] = i
+	}
+
+	view, viewRows := t, rows
+
+	for len(visible) > 1 && !view.fits(viewRows) {
+		visible = t.dropLowestPriority(visible)
+		view, viewRows = t.projectColumns(rows, visible)
+	}
+
+	return view, viewRows
+}
+
+// fits returns true if the minimum width of the columns of rows fits into the
+// maximum table width.
+func (t *Table) fits(rows []*tableRow) bool {
+	spacingWidth, _ := t.calculateSpacing()
+	availWidth := t.maxWidth - spacingWidth
+
+	requested := measure.Sum(t.measureContent(rows, availWidth)...)
+
+	return requested.Minimum <= availWidth
+}
+
+// dropLowestPriority removes the column with the lowest priority from
+// visible. If multiple columns have the lowest priority, the rightmost one is
+// removed.
+func (t *Table) dropLowestPriority(visible []int) []int {
+	lowest := len(visible) - 1
+
+	for i := len(visible) - 2; i >= 0; i-- {
+		if t.columnPriority(visible[i]) < t.columnPriority(visible[lowest]) {
+			lowest = i
+		}
+	}
+
+	return append(visible[:lowest:lowest], visible[lowest+1:]...)
+}
+
+// projectColumns creates a copy of t and rows that only contains the visible
+// columns. If a hidden column marker is configured, it is added as an
+// additional last column. Cells spanning hidden columns are shrunk, cells that
+// only cover hidden columns are dropped.
+func (t *Table) projectColumns(rows []*tableRow, visible []int) (*Table, []*tableRow) {
+	view := *t
+	view.numCols = len(visible)
+	view.columnWidths = make([]ColumnWidth, len(visible))
+	view.columnVerticalAlignment = make([]text.VerticalAlignment, len(visible))
+
+	// index maps table columns to the index of the visible column they are
+	// displayed in, or -1 if they are hidden.
+	index := make([]int, t.numCols)
+	for i := range index {
+		index[i] = -1
+	}
+
+	for i, col := range visible {
+		index[col] = i
+		view.columnWidths[i] = t.columnWidth(col)
+		view.columnVerticalAlignment[i] = t.cellVerticalAlignment(col)
+	}
+
+	if t.hiddenColumnMarker != "" {
+		width := runewidth.StringWidth(t.hiddenColumnMarker)
+
+		view.numCols++
+		view.columnWidths = append(view.columnWidths, ColumnWidth{Fixed: width})
+		view.columnVerticalAlignment = append(view.columnVerticalAlignment, t.verticalAlignment)
+	}
+
+	viewRows := make([]*tableRow, len(rows))
+
+	for i, row := range rows {
+		cells := make([]*tableCell, view.numCols)
+		columns := make([]int, view.numCols)
+
+		for j := range columns {
+			columns[j] = -1
+		}
+
+		for j, cell := range row.cells {
+			if cell == nil {
+				continue
+			}
+
+			start, colSpan := -1, 0
+
+			for k := j; k < j+cell.colSpan; k++ {
+				if index[k] < 0 {
+					continue
+				}
+
+				if start < 0 {
+					start = index[k]
+				}
+
+				colSpan++
+			}
+
+			if start < 0 {
+				continue
+			}
+
+			c := *cell
+			c.colSpan = colSpan
+			cells[start] = &c
+			columns[start] = j
+		}
+
+		if t.hiddenColumnMarker != "" {
+			cells[len(cells)-1] = t.makeCell(t.hiddenColumnMarker, t.numCols)
+		}
+
+		viewRows[i] = &tableRow{kind: row.kind, cells: cells, group: row.group, source: row, columns: columns}
+	}
+
+	return &view, viewRows
+}
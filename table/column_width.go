@@ -0,0 +1,156 @@
+package table
+
+import (
+	"github.com/martinohmann/neat/internal/util"
+	"github.com/martinohmann/neat/measure"
+)
+
+// ColumnWidth constrains the width of a table column. The zero value does not
+// constrain the column width at all.
+type ColumnWidth struct {
+	// Fixed sets a fixed column width if > 0. All other constraints are
+	// ignored in this case.
+	Fixed int
+	// Min sets the minimum column width if > 0. Columns with minimum width
+	// are the last to be truncated if the table does not fit into the
+	// maximum width.
+	Min int
+	// Max sets the maximum column width if > 0.
+	Max int
+	// Flex sets the ratio of the remaining table width that the column
+	// should occupy if > 0. The remaining width is the maximum table width
+	// minus the width of all columns without Flex. E.g. two columns with a
+	// Flex of 3 and 1 share the remaining width in a ratio of 3:1. Columns
+	// with Flex ignore the width of their contents but honour Min and Max.
+	Flex int
+}
+
+// constrain applies the width constraints to m.
+func (w ColumnWidth) constrain(m measure.Measurement) measure.Measurement {
+	if w.Fixed > 0 {
+		return measure.NewMeasurement(w.Fixed, w.Fixed)
+	}
+
+	if w.Min > 0 {
+		m.Minimum = util.MaxInt(m.Minimum, w.Min)
+		m.Maximum = util.MaxInt(m.Maximum, w.Min)
+	}
+
+	if w.Max > 0 {
+		m.Minimum = util.MinInt(m.Minimum, w.Max)
+		m.Maximum = util.MinInt(m.Maximum, w.Max)
+	}
+
+	return m
+}
+
+// hasMinimum returns true if the column has a fixed or minimum width.
+func (w ColumnWidth) hasMinimum() bool {
+	return w.Fixed > 0 || w.Min > 0
+}
+
+// flexible returns true if the column shares the remaining table width with
+// other flexible columns.
+func (w ColumnWidth) flexible() bool {
+	return w.Fixed <= 0 && w.Flex > 0
+}
+
+// columnWidth returns the width constraints of the column at colIdx.
+func (t *Table) columnWidth(colIdx int) ColumnWidth {
+	if colIdx < len(t.columnWidths) {
+		return t.columnWidths[colIdx]
+	}
+
+	return ColumnWidth{}
+}
+
+// constrainColumns applies the configured column width constraints to
+// measures.
+func (t *Table) constrainColumns(measures []measure.Measurement) {
+	for i, m := range measures {
+		measures[i] = t.columnWidth(i).constrain(m)
+	}
+}
+
+// flexColumns distributes the width that is left after allocating the maximum
+// width of all columns without flex ratio among the flexible columns. Returns
+// false if there are no flexible columns or if their minimum widths do not fit
+// into the remaining width.
+func (t *Table) flexColumns(measures []measure.Measurement, availWidth int) bool {
+	var flexible []int
+
+	remainingWidth := availWidth
+	minFlexWidth := 0
+
+	for i, m := range measures {
+		width := t.columnWidth(i)
+
+		if width.flexible() {
+			flexible = append(flexible, i)
+			minFlexWidth += width.Min
+			continue
+		}
+
+		remainingWidth -= m.Maximum
+	}
+
+	if len(flexible) == 0 || minFlexWidth > remainingWidth {
+		return false
+	}
+
+	widths := make(map[int]int, len(flexible))
+
+	// Distribute the remaining width according to the flex ratios. Columns
+	// whose share violates their width constraints are clamped and removed
+	// from the distribution, which is then repeated for the other columns.
+	for len(flexible) > 0 {
+		totalFlex := 0
+
+		for _, i := range flexible {
+			totalFlex += t.columnWidth(i).Flex
+		}
+
+		clamped := false
+		distributable := remainingWidth
+
+		for j, i := range flexible {
+			width := t.columnWidth(i)
+
+			share := distributable * width.Flex / totalFlex
+			if j == len(flexible)-1 {
+				// Last column receives the rounding remainder.
+				share = remainingWidth
+				for _, k := range flexible[:j] {
+					share -= widths[k]
+				}
+			}
+
+			widths[i] = share
+
+			if width.Min > 0 && share < width.Min {
+				widths[i] = width.Min
+				clamped = true
+			} else if width.Max > 0 && share > width.Max {
+				widths[i] = width.Max
+				clamped = true
+			}
+
+			if clamped {
+				remainingWidth -= widths[i]
+				flexible = append(flexible[:j], flexible[j+1:]...)
+				break
+			}
+		}
+
+		if !clamped {
+			break
+		}
+	}
+
+	for i, width := range widths {
+		width = util.MaxInt(0, width)
+		measures[i] = measure.NewMeasurement(width, width)
+	}
+
+	return true
+}
@@ -0,0 +1,84 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrStreamClosed is returned when rows are added to a *Stream after it was
+// closed.
+var ErrStreamClosed = errors.New("stream is closed")
+
+// ColumnMismatchError is returned when a row is added whose number of columns
+// does not match the number of columns of previously added rows.
+type ColumnMismatchError struct {
+	// Expected is the number of table columns.
+	Expected int
+	// Actual is the number of columns the row would occupy. This includes
+	// the columns spanned by its cells and the columns that are covered by
+	// cells of previous rows spanning multiple rows.
+	Actual int
+	// Covered is the number of columns included in Actual that are covered
+	// by cells of previous rows spanning multiple rows.
+	Covered int
+}
+
+// Error implements error.
+func (e *ColumnMismatchError) Error() string {
+	if e.Covered > 0 {
+		return fmt.Sprintf("expected %d columns, got %d (%d covered by cells of previous rows)", e.Expected, e.Actual, e.Covered)
+	}
+
+	return fmt.Sprintf("expected %d columns, got %d", e.Expected, e.Actual)
+}
+
+// RowOrderError is returned when a row is added in the wrong order, e.g. a
+// header row after a normal row or a normal row after a footer row.
+type RowOrderError struct {
+	// Kind is the kind of the row that was added, e.g. "header".
+	Kind string
+	// PrevKind is the kind of the previously added row.
+	PrevKind string
+}
+
+// Error implements error.
+func (e *RowOrderError) Error() string {
+	return fmt.Sprintf("cannot add %s row after %s row", e.Kind, e.PrevKind)
+}
+
+// SpanOverlapError is returned when a cell spanning multiple columns overlaps
+// with a cell of a previous row which spans multiple rows.
+type SpanOverlapError struct {
+	// Column is the index of the column where the overlapping cell starts.
+	Column int
+	// SpanColumn is the index of the first column that is already covered.
+	SpanColumn int
+}
+
+// Error implements error.
+func (e *SpanOverlapError) Error() string {
+	return fmt.Sprintf("cell in column %d overlaps with cell spanning multiple rows in column %d", e.Column, e.SpanColumn)
+}
+
+// UnknownBorderStyleError is returned by BorderStyleByName if there is no
+// border style with the requested name.
+type UnknownBorderStyleError struct {
+	Name string
+}
+
+// Error implements error.
+func (e *UnknownBorderStyleError) Error() string {
+	return fmt.Sprintf("unknown border style %q", e.Name)
+}
+
+// PageRangeError is returned by RenderPage if the requested page does not
+// exist.
+type PageRangeError struct {
+	Page  int
+	Count int
+}
+
+// Error implements error.
+func (e *PageRangeError) Error() string {
+	return fmt.Sprintf("page %d out of range, table has %d pages", e.Page, e.Count)
+}
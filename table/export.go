@@ -0,0 +1,232 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/acarl005/stripansi"
+	"github.com/martinohmann/neat/console"
+	"github.com/martinohmann/neat/internal/util"
+	"github.com/martinohmann/neat/text"
+)
+
+// Exporter exports table data into a structured format like CSV or JSON.
+type Exporter interface {
+	// Export writes data to w. Returns an error if exporting failed.
+	Export(w io.Writer, data *ExportData) error
+}
+
+// ExporterFunc is a func that satisfies the Exporter interface.
+type ExporterFunc func(w io.Writer, data *ExportData) error
+
+// Export implements Exporter.
+func (f ExporterFunc) Export(w io.Writer, data *ExportData) error {
+	return f(w, data)
+}
+
+// ExportCell contains the plain text value of a table cell together with its
+// span information. Row spans never exceed the last row of the section
+// (header, normal or footer rows) the cell belongs to.
+type ExportCell struct {
+	Text    string
+	ColSpan int
+	RowSpan int
+}
+
+// ExportRow is a row of table data. Grid slots which are covered by cells
+// spanning multiple columns or rows are nil.
+type ExportRow []*ExportCell
+
+// Strings returns the text of all cells in the row. Grid slots covered by
+// other cells produce empty strings.
+func (r ExportRow) Strings() []string {
+	values := make([]string, len(r))
+
+	for i, cell := range r {
+		if cell != nil {
+			values[i] = cell.Text
+		}
+	}
+
+	return values
+}
+
+// ExportData is a plain text representation of the contents of a *Table. ANSI
+// escape sequences are stripped from all cell values.
+type ExportData struct {
+	Header []ExportRow
+	Rows   []ExportRow
+	Footer []ExportRow
+
+	// Alignment contains the alignment of each table column as configured
+	// via WithAlignment and WithColumnAlignment.
+	Alignment []text.Alignment
+}
+
+// AllRows returns the header, normal and footer rows in this order.
+func (d *ExportData) AllRows() []ExportRow {
+	rows := make([]ExportRow, 0, len(d.Header)+len(d.Rows)+len(d.Footer))
+	rows = append(rows, d.Header...)
+	rows = append(rows, d.Rows...)
+	return append(rows, d.Footer...)
+}
+
+// Keys returns a name for each column that is derived from the header rows.
+// For each column the last header row with a non-empty cell starting in that
+// column wins. Columns without a name produce empty strings.
+func (d *ExportData) Keys() []string {
+	keys := make([]string, len(d.Alignment))
+
+	for i := range keys {
+		for j := len(d.Header) - 1; j >= 0; j-- {
+			if cell := d.Header[j][i]; cell != nil && cell.Text != "" {
+				keys[i] = cell.Text
+				break
+			}
+		}
+	}
+
+	return keys
+}
+
+// Export exports the table data to the underlying io.Writer using exporter.
+// Returns an error if exporting failed.
+func (t *Table) Export(exporter Exporter) error {
+	return exporter.Export(t.out, t.ExportData())
+}
+
+// ExportData returns a plain text representation of the table contents. The
+// configured sorting, filtering and grouping is applied to the rows.
+// Values implementing console.Renderable are rendered using their maximum
+// requested width, unless they are of type text.Text in which case their
+// unaltered Text is used.
+func (t *Table) ExportData() *ExportData {
+	data := &ExportData{
+		Alignment: make([]text.Alignment, t.numCols),
+	}
+
+	for i := range data.Alignment {
+		data.Alignment[i] = t.alignment
+
+		if i < len(t.columnAlignment) {
+			data.Alignment[i] = t.columnAlignment[i]
+		}
+	}
+
+	for _, row := range t.arrangeRows() {
+		exportRow := make(ExportRow, len(row.cells))
+
+		for i, cell := range row.cells {
+			if cell == nil {
+				continue
+			}
+
+			exportRow[i] = &ExportCell{
+				Text:    plainText(cell.value, t.maxWidth),
+				ColSpan: cell.colSpan,
+				RowSpan: cell.rowSpan,
+			}
+		}
+
+		switch row.kind {
+		case rowKindHeader:
+			data.Header = append(data.Header, exportRow)
+		case rowKindFooter:
+			data.Footer = append(data.Footer, exportRow)
+		default:
+			data.Rows = append(data.Rows, exportRow)
+		}
+	}
+
+	for _, rows := range [][]ExportRow{data.Header, data.Rows, data.Footer} {
+		clipExportRowSpans(rows)
+	}
+
+	return data
+}
+
+// clipExportRowSpans limits the row spans of the cells in rows to the number
+// of rows that remain below them.
+func clipExportRowSpans(rows []ExportRow) {
+	for i, row := range rows {
+		for _, cell := range row {
+			if cell != nil && cell.RowSpan > len(rows)-i {
+				cell.RowSpan = len(rows) - i
+			}
+		}
+	}
+}
+
+// spannedStrings returns the text of all cells in rows. Grid slots covered by
+// cells spanning multiple columns or rows contain the text of the spanning
+// cell.
+func spannedStrings(rows []ExportRow) [][]string {
+	result := make([][]string, len(rows))
+
+	// covering contains the cell that covers each column and the number of
+	// upcoming rows it still covers.
+	type covering struct {
+		text string
+		rows int
+	}
+
+	var covered []covering
+
+	for i, row := range rows {
+		if covered == nil {
+			covered = make([]covering, len(row))
+		}
+
+		values := make([]string, len(row))
+
+		for j := 0; j < len(row); {
+			cell := row[j]
+			if cell == nil {
+				if covered[j].rows > 0 {
+					values[j] = covered[j].text
+					covered[j].rows--
+				}
+
+				j++
+				continue
+			}
+
+			for k := j; k < j+cell.ColSpan && k < len(row); k++ {
+				values[k] = cell.Text
+				covered[k] = covering{cell.Text, cell.RowSpan - 1}
+			}
+
+			j += util.MaxInt(1, cell.ColSpan)
+		}
+
+		result[i] = values
+	}
+
+	return result
+}
+
+// plainText returns the text of v with all ANSI escape sequences removed.
+// Values implementing console.Renderable are rendered using their maximum
+// requested width for maxWidth.
+func plainText(v interface{}, maxWidth int) string {
+	var s string
+
+	switch r := v.(type) {
+	case text.Text:
+		s = r.Text
+	case *text.Text:
+		s = r.Text
+	case console.Renderable:
+		lines := text.SplitLines(r.Render(r.Measure(maxWidth).Maximum))
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " ")
+		}
+
+		s = text.JoinLines(lines)
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	return stripansi.Strip(s)
+}
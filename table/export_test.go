@@ -0,0 +1,135 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/martinohmann/neat/style"
+	"github.com/martinohmann/neat/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func newExportTable(buf *bytes.Buffer) *Table {
+	return New(buf, WithColumnAlignment(text.AlignLeft, text.AlignRight, text.AlignCenter)).
+		AddHeader(Cell{Value: "name", RowSpan: 2}, Cell{Value: "network", ColSpan: 2}).
+		AddHeader("rx", "tx").
+		AddRow(style.New(style.Bold).Sprint("node-1"), 10, text.Text{Text: "20"}).
+		AddRow("node|2", "a,b", "multi\nline").
+		AddFooter("total", 10, 20)
+}
+
+func TestTable_Export_CSV(t *testing.T) {
+	defer style.Enable()()
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, newExportTable(&buf).Export(NewCSVExporter()))
+	assert.Equal(t, `name,network,
+,rx,tx
+node-1,10,20
+node|2,"a,b","multi
+line"
+total,10,20
+`, buf.String())
+
+	buf.Reset()
+
+	assert.NoError(t, newExportTable(&buf).Export(NewTSVExporter()))
+	assert.Equal(t, "name\tnetwork\t\n\trx\ttx\nnode-1\t10\t20\nnode|2\ta,b\t\"multi\nline\"\ntotal\t10\t20\n", buf.String())
+}
+
+func TestTable_Export_JSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, newExportTable(&buf).Export(JSONExporter{}))
+	assert.Equal(t, `[{"name":"node-1","rx":"10","tx":"20"},{"name":"node|2","rx":"a,b","tx":"multi\nline"}]`+"\n", buf.String())
+
+	buf.Reset()
+
+	assert.NoError(t, New(&buf).AddRow("foo", "bar").Export(NewJSONExporter()))
+	assert.Equal(t, `[
+  {
+    "0": "foo",
+    "1": "bar"
+  }
+]
+`, buf.String())
+}
+
+func TestTable_Export_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, newExportTable(&buf).Export(MarkdownExporter{}))
+	assert.Equal(t, `| name | rx | tx |
+| --- | ---: | :---: |
+| node-1 | 10 | 20 |
+| node\|2 | a,b | multi<br>line |
+| **total** | **10** | **20** |
+`, buf.String())
+}
+
+func TestTable_Export_HTML(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, newExportTable(&buf).Export(HTMLExporter{}))
+	assert.Equal(t, `<table>
+  <thead>
+    <tr>
+      <th rowspan="2">name</th>
+      <th colspan="2" style="text-align: right">network</th>
+    </tr>
+    <tr>
+      <th style="text-align: right">rx</th>
+      <th style="text-align: center">tx</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <td>node-1</td>
+      <td style="text-align: right">10</td>
+      <td style="text-align: center">20</td>
+    </tr>
+    <tr>
+      <td>node|2</td>
+      <td style="text-align: right">a,b</td>
+      <td style="text-align: center">multi<br>line</td>
+    </tr>
+  </tbody>
+  <tfoot>
+    <tr>
+      <td>total</td>
+      <td style="text-align: right">10</td>
+      <td style="text-align: center">20</td>
+    </tr>
+  </tfoot>
+</table>
+`, buf.String())
+}
+
+func TestTable_ExportData_RowSpans(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+
+	tab := New(&buf).
+		AddHeader("name", "zone").
+		AddRow("a", Cell{Value: "x", RowSpan: 5}).
+		AddRow("b").
+		AddRow("c")
+
+	data := tab.ExportData()
+	assert.Equal(3, data.Rows[0][1].RowSpan)
+
+	assert.NoError(tab.Export(JSONExporter{}))
+	assert.Equal(`[{"name":"a","zone":"x"},{"name":"b","zone":"x"},{"name":"c","zone":"x"}]`+"\n", buf.String())
+
+	buf.Reset()
+
+	assert.NoError(New(&buf).AddHeader("a", "b").AddRow(Cell{Value: "c", ColSpan: 2}).Export(JSONExporter{}))
+	assert.Equal(`[{"a":"c","b":"c"}]`+"\n", buf.String())
+
+	buf.Reset()
+
+	assert.NoError(New(&buf).AddRow("a", Cell{Value: "x", RowSpan: 5}).AddRow("b").Export(HTMLExporter{}))
+	assert.Contains(buf.String(), `<td rowspan="2">x</td>`)
+}
@@ -0,0 +1,271 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/martinohmann/neat/text"
+)
+
+// CSVExporter exports table data as character separated values. Header,
+// normal and footer rows are exported in this order. Grid slots covered by
+// cells spanning multiple columns or rows are exported as empty fields.
+type CSVExporter struct {
+	// Comma is the field delimiter. Defaults to ',' if unset.
+	Comma rune
+}
+
+// NewCSVExporter creates a new CSVExporter which produces comma separated
+// values.
+func NewCSVExporter() CSVExporter {
+	return CSVExporter{Comma: ','}
+}
+
+// NewTSVExporter creates a new CSVExporter which produces tab separated
+// values.
+func NewTSVExporter() CSVExporter {
+	return CSVExporter{Comma: '\t'}
+}
+
+// Export implements Exporter.
+func (e CSVExporter) Export(w io.Writer, data *ExportData) error {
+	cw := csv.NewWriter(w)
+
+	if e.Comma != 0 {
+		cw.Comma = e.Comma
+	}
+
+	for _, row := range data.AllRows() {
+		if err := cw.Write(row.Strings()); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// JSONExporter exports table data as a JSON array of objects. The object keys
+// are taken from the header rows, see ExportData.Keys. Columns without header
+// use their column index as key. Keys of grid slots covered by cells spanning
+// multiple columns or rows get the value of the spanning cell, so that each
+// object is a complete record. Only normal rows are exported. Footer rows
+// usually contain summaries like totals which cannot be told apart from data
+// records in the JSON array, so they are left out. They are available via
+// ExportData.Footer for custom exporters.
+type JSONExporter struct {
+	// Indent is used to indent the JSON output if non-empty.
+	Indent string
+}
+
+// NewJSONExporter creates a new JSONExporter which produces indented JSON.
+func NewJSONExporter() JSONExporter {
+	return JSONExporter{Indent: "  "}
+}
+
+// Export implements Exporter.
+func (e JSONExporter) Export(w io.Writer, data *ExportData) error {
+	keys := data.Keys()
+
+	for i, key := range keys {
+		if key == "" {
+			keys[i] = strconv.Itoa(i)
+		}
+	}
+
+	// We build the JSON manually to preserve the column order of the
+	// objects.
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+
+	for i, values := range spannedStrings(data.Rows) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteByte('{')
+
+		for j, value := range values {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeJSONField(&buf, keys[j], value); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	}
+
+	buf.WriteByte(']')
+
+	if e.Indent != "" {
+		var indented bytes.Buffer
+
+		if err := json.Indent(&indented, buf.Bytes(), "", e.Indent); err != nil {
+			return err
+		}
+
+		buf = indented
+	}
+
+	buf.WriteByte('\n')
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func writeJSONField(buf *bytes.Buffer, key, value string) error {
+	k, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(k)
+	buf.WriteByte(':')
+	buf.Write(v)
+
+	return nil
+}
+
+// MarkdownExporter exports table data as GitHub flavoured markdown table.
+// Since markdown tables only support a single header row, the column names
+// are derived from all header rows, see ExportData.Keys. The column alignment
+// is taken from ExportData.Alignment. Since markdown tables do not have a
+// footer section either, footer rows are exported after the normal rows with
+// their non-empty values in bold.
+type MarkdownExporter struct{}
+
+// Export implements Exporter.
+func (e MarkdownExporter) Export(w io.Writer, data *ExportData) error {
+	var sb strings.Builder
+
+	writeMarkdownRow(&sb, data.Keys())
+
+	sb.WriteByte('|')
+
+	for _, alignment := range data.Alignment {
+		switch alignment {
+		case text.AlignRight:
+			sb.WriteString(" ---: |")
+		case text.AlignCenter:
+			sb.WriteString(" :---: |")
+		default:
+			sb.WriteString(" --- |")
+		}
+	}
+
+	sb.WriteByte('\n')
+
+	for _, row := range data.Rows {
+		writeMarkdownRow(&sb, row.Strings())
+	}
+
+	for _, row := range data.Footer {
+		values := row.Strings()
+
+		for i, value := range values {
+			if value != "" {
+				values[i] = "**" + value + "**"
+			}
+		}
+
+		writeMarkdownRow(&sb, values)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\n", "<br>")
+
+func writeMarkdownRow(sb *strings.Builder, values []string) {
+	sb.WriteByte('|')
+
+	for _, value := range values {
+		sb.WriteByte(' ')
+		sb.WriteString(markdownReplacer.Replace(value))
+		sb.WriteString(" |")
+	}
+
+	sb.WriteByte('\n')
+}
+
+// HTMLExporter exports table data as HTML table. Cells spanning multiple
+// columns or rows are exported using colspan and rowspan attributes.
+type HTMLExporter struct{}
+
+// Export implements Exporter.
+func (e HTMLExporter) Export(w io.Writer, data *ExportData) error {
+	var sb strings.Builder
+
+	sb.WriteString("<table>\n")
+
+	writeHTMLSection(&sb, "thead", "th", data.Header, data.Alignment)
+	writeHTMLSection(&sb, "tbody", "td", data.Rows, data.Alignment)
+	writeHTMLSection(&sb, "tfoot", "td", data.Footer, data.Alignment)
+
+	sb.WriteString("</table>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeHTMLSection(sb *strings.Builder, section, tag string, rows []ExportRow, alignment []text.Alignment) {
+	if len(rows) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "  <%s>\n", section)
+
+	for _, row := range rows {
+		sb.WriteString("    <tr>\n")
+
+		for i, cell := range row {
+			if cell == nil {
+				continue
+			}
+
+			fmt.Fprintf(sb, "      <%s", tag)
+
+			if cell.ColSpan > 1 {
+				fmt.Fprintf(sb, ` colspan="%d"`, cell.ColSpan)
+			}
+
+			if cell.RowSpan > 1 {
+				fmt.Fprintf(sb, ` rowspan="%d"`, cell.RowSpan)
+			}
+
+			switch alignment[i] {
+			case text.AlignRight:
+				sb.WriteString(` style="text-align: right"`)
+			case text.AlignCenter:
+				sb.WriteString(` style="text-align: center"`)
+			case text.AlignJustify:
+				sb.WriteString(` style="text-align: justify"`)
+			}
+
+			value := strings.ReplaceAll(html.EscapeString(cell.Text), "\n", "<br>")
+
+			fmt.Fprintf(sb, ">%s</%s>\n", value, tag)
+		}
+
+		sb.WriteString("    </tr>\n")
+	}
+
+	fmt.Fprintf(sb, "  </%s>\n", section)
+}
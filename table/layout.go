@@ -0,0 +1,144 @@
+package table
+
+import (
+	"strconv"
+
+	"github.com/martinohmann/neat/internal/util"
+)
+
+// Layout controls how table rows are laid out.
+type Layout int
+
+const (
+	// LayoutHorizontal renders table rows below each other with their cells
+	// side by side. This is the default.
+	LayoutHorizontal Layout = iota
+	// LayoutExpanded renders each normal and footer row as a block of
+	// "key: value" lines, similar to the expanded display of psql. Keys are
+	// taken from the last header row or are the column numbers if there is
+	// no header row. Blocks are separated by section borders if enabled or
+	// blank lines otherwise.
+	LayoutExpanded
+	// LayoutAuto uses LayoutExpanded if the table does not fit into the
+	// maximum width without truncating columns below their minimum width and
+	// LayoutHorizontal otherwise. Columns with low priority are hidden before
+	// switching to LayoutExpanded if column priorities are configured.
+	LayoutAuto
+)
+
+// view returns the *Table and rows to render after applying column hiding
+// and the configured layout.
+func (t *Table) view(rows []*tableRow) (*Table, []*tableRow) {
+	switch t.rowLayout {
+	case LayoutExpanded:
+		return t.expand(rows)
+	case LayoutAuto:
+		view, viewRows := t.hideColumns(rows)
+		if !view.fits(viewRows) {
+			return t.expand(rows)
+		}
+
+		return view, viewRows
+	default:
+		return t.hideColumns(rows)
+	}
+}
+
+// expand creates a copy of t and rows for rendering rows in expanded layout.
+// The copy has a key and a value column. If there are no column borders, an
+// additional column containing ":" is placed between them.
+func (t *Table) expand(rows []*tableRow) (*Table, []*tableRow) {
+	view := *t
+	view.numCols = 2
+	view.columnWidths = nil
+	view.columnVerticalAlignment = nil
+	view.columnPriorities = nil
+
+	separated := !t.borderMask.Has(BorderColumn)
+	if separated {
+		view.numCols++
+	}
+
+	var header []*tableRow
+	for _, row := range rows {
+		if row.kind == rowKindHeader {
+			header = append(header, row)
+		}
+	}
+
+	var viewRows []*tableRow
+
+	record := 0
+
+	for _, row := range rows {
+		if row.kind == rowKindHeader {
+			continue
+		}
+
+		if record > 0 && !t.borderMask.Has(BorderSection) {
+			// Separate records by a blank line.
+			cells := make([]*tableCell, view.numCols)
+			cells[0] = t.makeCell(Cell{Value: "", ColSpan: view.numCols}, 0)
+			viewRows = append(viewRows, &tableRow{kind: rowKindNormal, cells: cells, group: record, source: row, sourceCol: -1})
+		}
+
+		for i, cell := range row.cells {
+			if cell == nil {
+				continue
+			}
+
+			value := *cell
+			value.colSpan, value.rowSpan = 1, 1
+
+			cells := []*tableCell{t.expandedKey(header, i)}
+
+			if separated {
+				cells = append(cells, t.makeCell(":", t.numCols))
+			}
+
+			cells = append(cells, &value)
+
+			viewRows = append(viewRows, &tableRow{kind: rowKindNormal, cells: cells, group: record, source: row, sourceCol: i})
+		}
+
+		record++
+	}
+
+	// The key column should not take more width than needed so that the
+	// value column can use the remaining width. It takes at most half of the
+	// table width.
+	keyWidth := 0
+	for _, row := range viewRows {
+		if row.cells[0].colSpan == 1 {
+			keyWidth = util.MaxInt(keyWidth, row.cells[0].Measure(t.maxWidth).Maximum)
+		}
+	}
+
+	view.columnWidths = []ColumnWidth{{Fixed: util.MinInt(keyWidth, t.maxWidth/2)}}
+
+	return &view, viewRows
+}
+
+// expandedKey returns the key cell for the column at colIdx, that is the cell
+// of the last header row covering the column. Falls back to the column number
+// if there is no such cell.
+func (t *Table) expandedKey(header []*tableRow, colIdx int) *tableCell {
+	for i := len(header) - 1; i >= 0; i-- {
+		for j := colIdx; j >= 0; j-- {
+			cell := header[i].cells[j]
+			if cell == nil {
+				continue
+			}
+
+			if j+cell.colSpan > colIdx {
+				key := *cell
+				key.colSpan, key.rowSpan = 1, 1
+				return &key
+			}
+
+			break
+		}
+	}
+
+	return t.makeCell(strconv.Itoa(colIdx+1), colIdx)
+}
@@ -0,0 +1,61 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Render_LayoutExpanded(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := New(&buf, WithLayout(LayoutExpanded), WithBorder(BorderStyleASCII)).
+		AddHeader("name", Cell{Value: "state", ColSpan: 2}).
+		AddRow("nginx", "Running", "ready").
+		AddRow("redis", Cell{Value: "Pending", ColSpan: 2}).
+		Render()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `+-------+---------+
+| name  | nginx   |
+| state | Running |
+| state | ready   |
++=======+=========+
+| name  | redis   |
+| state | Pending |
++-------+---------+
+`, buf.String())
+}
+
+func TestTable_Render_LayoutAuto(t *testing.T) {
+	render := func(maxWidth int) string {
+		var buf bytes.Buffer
+
+		err := New(&buf, WithLayout(LayoutAuto), WithMaxWidth(maxWidth), WithColumnWordWrap(false, true)).
+			AddRow("nginx", "a web server").
+			AddRow("redis", "cache").
+			Render()
+
+		assert.NoError(t, err)
+
+		return buf.String()
+	}
+
+	assert.Equal(t, "nginx a web server\nredis cache       \n", render(18))
+	assert.Equal(t, "1 : nginx \n2 : a web \n    server\n          \n1 : redis \n2 : cache \n", render(10))
+}
+
+func TestTable_Render_HeaderOnly(t *testing.T) {
+	for _, layout := range []Layout{LayoutExpanded, LayoutAuto} {
+		var buf bytes.Buffer
+
+		tab := New(&buf, WithLayout(layout), WithMaxWidth(3)).
+			AddHeader("name", "state")
+
+		assert.NoError(t, tab.Render())
+		assert.Equal(t, "", buf.String())
+		assert.Equal(t, 0, tab.PageCount())
+		assert.NotPanics(t, func() { tab.Renderable().Render(3) })
+	}
+}
@@ -9,10 +9,25 @@ import (
 type Option func(t *Table)
 
 // WithPadding sets the horizontal padding between adjacent table cells.
-// Defaults to 1.
+// Defaults to 1. Use WithCellPadding to configure each side separately.
 func WithPadding(padding int) Option {
 	return func(t *Table) {
-		t.padding = padding
+		t.paddingLeft = padding
+		t.paddingRight = padding
+	}
+}
+
+// WithCellPadding sets the padding on each side of every table cell. The
+// vertical padding adds blank lines above and below the cell content and is
+// not subject to the minimum and maximum row height. Without column borders
+// the left and right padding of adjacent cells collapse and the larger one of
+// both is used.
+func WithCellPadding(top, right, bottom, left int) Option {
+	return func(t *Table) {
+		t.paddingTop = top
+		t.paddingRight = right
+		t.paddingBottom = bottom
+		t.paddingLeft = left
 	}
 }
 
@@ -23,6 +38,16 @@ func WithMargin(margin int) Option {
 	}
 }
 
+// WithVerticalMargin sets the number of blank lines written above and below
+// the table. If the table is split into pages, the margin surrounds every
+// page. Defaults to 0.
+func WithVerticalMargin(top, bottom int) Option {
+	return func(t *Table) {
+		t.marginTop = top
+		t.marginBottom = bottom
+	}
+}
+
 // WithMaxWidth sets the maximum table width. If maxWidth is <= 0, maxWidth is
 // inferred from the table's underlying io.Writer if it is a
 // console.FileWriter, otherwise a default of 80 is used.
@@ -51,8 +76,18 @@ func WithBorderRunes(runes BorderRunes) Option {
 	}
 }
 
+// WithRenderer sets the *style.Renderer that creates the escape sequences
+// for all table styles. It also decides whether the table output is
+// colorized at all. Defaults to the renderer for the table's io.Writer, see
+// style.RendererFor.
+func WithRenderer(r *style.Renderer) Option {
+	return func(t *Table) {
+		t.renderer = r
+	}
+}
+
 // WithBorderStyle sets the style the should be applied to each border element.
-// The default is to not apply any style.
+// Defaults to the "table.border" style of the active theme.
 func WithBorderStyle(style *style.Style) Option {
 	return func(t *Table) {
 		t.borderStyle = style
@@ -115,3 +150,292 @@ func WithColumnWordWrap(wrap ...bool) Option {
 		t.columnWordWrap = wrap
 	}
 }
+
+// WithPadShortRows controls whether rows with less columns than the table are
+// padded with empty cells. If false, adding short rows causes an error.
+// Defaults to false.
+func WithPadShortRows(pad bool) Option {
+	return func(t *Table) {
+		t.padShortRows = pad
+	}
+}
+
+// WithTruncateLongRows controls whether rows with more columns than the table
+// are truncated to the table's number of columns. If false, adding long rows
+// causes an error. Defaults to false.
+func WithTruncateLongRows(truncate bool) Option {
+	return func(t *Table) {
+		t.truncateLongRows = truncate
+	}
+}
+
+// WithSortKeys sorts the normal table rows by the given keys before rendering.
+// Rows that compare equal for the first key are compared using the next key
+// and so on. Sorting is stable, rows that are equal for all keys keep the
+// order in which they were added. Header and footer rows are not sorted. Rows
+// tied together by cells spanning multiple rows are sorted as a unit based on
+// the values of their first row.
+func WithSortKeys(keys ...SortKey) Option {
+	return func(t *Table) {
+		t.sortKeys = keys
+	}
+}
+
+// WithRowFilter only renders normal table rows for which filter returns true.
+// Header and footer rows are always rendered. Rows tied together by cells
+// spanning multiple rows are filtered as a unit based on the values of their
+// first row.
+func WithRowFilter(filter RowFilter) Option {
+	return func(t *Table) {
+		t.rowFilter = filter
+	}
+}
+
+// WithGroupBy groups the normal table rows by the text of the column at index
+// column. Groups are ordered by the first appearance of their value after
+// sorting was applied. If BorderSection is enabled, section borders are drawn
+// between adjacent groups.
+func WithGroupBy(column int) Option {
+	return func(t *Table) {
+		t.groupRows = true
+		t.groupColumn = column
+	}
+}
+
+// WithColumnAggregates configures aggregates per column. If set, a footer row
+// is generated automatically from the values of the normal table rows that are
+// left after filtering. The generated footer is rendered before footer rows
+// added via AddFooter. Columns without aggregate (nil) produce empty footer
+// cells. Example:
+//
+//	table.WithColumnAggregates(
+//	  &table.Aggregate{Label: "Total"},
+//	  nil,
+//	  &table.Aggregate{Func: table.Sum},
+//	)
+func WithColumnAggregates(aggregates ...*Aggregate) Option {
+	return func(t *Table) {
+		t.aggregates = aggregates
+	}
+}
+
+// WithVerticalAlignment sets the default vertical alignment for all table
+// cells. It controls where cells that have less lines than the table row are
+// placed. Can be overridden per table column via WithColumnVerticalAlignment.
+// Defaults to text.AlignTop.
+func WithVerticalAlignment(alignment text.VerticalAlignment) Option {
+	return func(t *Table) {
+		t.verticalAlignment = alignment
+	}
+}
+
+// WithColumnVerticalAlignment configures the vertical cell alignment per
+// column. See documentation of WithVerticalAlignment.
+func WithColumnVerticalAlignment(alignment ...text.VerticalAlignment) Option {
+	return func(t *Table) {
+		t.columnVerticalAlignment = alignment
+	}
+}
+
+// WithMinRowHeight sets the minimum number of lines of each table row. Rows
+// with less lines are filled with blank lines according to the vertical cell
+// alignment. Defaults to 1.
+func WithMinRowHeight(height int) Option {
+	return func(t *Table) {
+		t.minRowHeight = height
+	}
+}
+
+// WithMaxRowHeight sets the maximum number of lines of each table row. Cells
+// exceeding the maximum height are cut off and an ellipsis is displayed in
+// their last line. If height is <= 0, the row height is not limited, which is
+// the default.
+func WithMaxRowHeight(height int) Option {
+	return func(t *Table) {
+		t.maxRowHeight = height
+	}
+}
+
+// WithColumnWidth configures width constraints per column. Constraints are
+// honoured before the table falls back to truncating columns that do not fit
+// into the maximum table width. Columns with fixed or minimum width are only
+// truncated if there is no other option. For example, this lets the first
+// column take three parts and the second column one part of the width
+// remaining after all other columns are allocated:
+//
+//	table.WithColumnWidth(
+//		table.ColumnWidth{Flex: 3},
+//		table.ColumnWidth{Flex: 1, Min: 6},
+//		table.ColumnWidth{Fixed: 10},
+//	)
+func WithColumnWidth(widths ...ColumnWidth) Option {
+	return func(t *Table) {
+		t.columnWidths = widths
+	}
+}
+
+// WithColumnPriority configures the priority per column. If the minimum width
+// of all columns does not fit into the maximum table width, columns with the
+// lowest priority are hidden until the remaining columns fit. Columns with
+// equal priority are hidden from right to left. Columns without a configured
+// priority have priority 0. Columns are never hidden if no priorities are
+// configured, which is the default.
+func WithColumnPriority(priorities ...int) Option {
+	return func(t *Table) {
+		t.columnPriorities = priorities
+	}
+}
+
+// WithHiddenColumnMarker sets a marker that is displayed in an additional last
+// column of each row if columns were hidden because of their priority, e.g.
+// "»". No marker is displayed if marker is empty, which is the default. See
+// WithColumnPriority.
+func WithHiddenColumnMarker(marker string) Option {
+	return func(t *Table) {
+		t.hiddenColumnMarker = marker
+	}
+}
+
+// WithBorder applies the runes and mask of a border style preset. This is
+// equivalent to using WithBorderRunes and WithBorderMask. Use
+// BorderStyleByName to obtain a preset by its name, e.g. from a config file.
+func WithBorder(bs BorderStyle) Option {
+	return func(t *Table) {
+		t.borderRunes = bs.Runes.copy()
+		t.borderMask = bs.Mask
+	}
+}
+
+// WithTitle sets a title that is embedded into the top border of the table,
+// e.g. "┌─ Pods ───┐". If the top border is disabled, the title is rendered
+// on a separate line above the table. Titles that are too long are truncated.
+func WithTitle(title string) Option {
+	return func(t *Table) {
+		t.title = title
+	}
+}
+
+// WithTitleAlignment sets the alignment of the table title. Defaults to
+// text.AlignLeft.
+func WithTitleAlignment(alignment text.Alignment) Option {
+	return func(t *Table) {
+		t.titleAlignment = alignment
+	}
+}
+
+// WithTitleStyle sets the style that should be applied to the table title.
+// Defaults to the "table.title" style of the active theme.
+func WithTitleStyle(style *style.Style) Option {
+	return func(t *Table) {
+		t.titleStyle = style
+	}
+}
+
+// WithCaption sets a caption that is rendered below the table. Captions that
+// are wider than the table are wrapped onto multiple lines.
+func WithCaption(caption string) Option {
+	return func(t *Table) {
+		t.caption = caption
+	}
+}
+
+// WithCaptionAlignment sets the alignment of the table caption. Defaults to
+// text.AlignLeft.
+func WithCaptionAlignment(alignment text.Alignment) Option {
+	return func(t *Table) {
+		t.captionAlignment = alignment
+	}
+}
+
+// WithCaptionStyle sets the style that should be applied to the table
+// caption. Defaults to the "table.caption" style of the active theme.
+func WithCaptionStyle(style *style.Style) Option {
+	return func(t *Table) {
+		t.captionStyle = style
+	}
+}
+
+// WithStreamSampleSize sets the number of normal rows a *Stream samples to
+// determine the column widths before writing the first row. The header rows
+// are always taken into account. Defaults to 1. Has no effect on *Table.
+func WithStreamSampleSize(n int) Option {
+	return func(t *Table) {
+		t.streamSampleSize = n
+	}
+}
+
+// WithStreamHeaderInterval makes a *Stream re-emit the header rows after
+// every n normal rows. Rows tied together by cells spanning multiple rows are
+// never separated, so the header rows may be re-emitted earlier, or later if
+// such a block has more than n rows. If n <= 0, the header rows are only
+// written once, which is the default. Has no effect on *Table.
+func WithStreamHeaderInterval(n int) Option {
+	return func(t *Table) {
+		t.streamHeaderInterval = n
+	}
+}
+
+// WithPageSize splits the rendered table into pages of at most lines lines.
+// Each page has its own top and bottom border and repeats the header rows.
+// Use PageSizeTerminal to use the terminal height as page size if the table
+// is rendered to a terminal. If lines is 0, the table is not split into pages,
+// which is the default. See RenderPage and PageCount.
+func WithPageSize(lines int) Option {
+	return func(t *Table) {
+		t.pageSize = lines
+	}
+}
+
+// WithLayout controls how table rows are laid out. See the documentation of
+// the Layout constants for available layouts. Defaults to LayoutHorizontal.
+func WithLayout(layout Layout) Option {
+	return func(t *Table) {
+		t.rowLayout = layout
+	}
+}
+
+// WithHeaderStyle sets the style that is applied to header rows. Defaults to
+// the "table.header" style of the active theme.
+func WithHeaderStyle(style *style.Style) Option {
+	return func(t *Table) {
+		t.headerStyle = style
+	}
+}
+
+// WithFooterStyle sets the style that is applied to footer rows. Defaults to
+// the "table.footer" style of the active theme.
+func WithFooterStyle(style *style.Style) Option {
+	return func(t *Table) {
+		t.footerStyle = style
+	}
+}
+
+// WithRowStyles sets styles that are applied to normal rows in alternating
+// order, e.g. for zebra striping. The first style is applied to the first
+// normal row, the second style to the second row and so on. Background colors
+// also cover the padding between cells. Attributes set by column styles or the
+// cell content itself take precedence over row styles.
+func WithRowStyles(styles ...*style.Style) Option {
+	return func(t *Table) {
+		t.rowStyles = styles
+	}
+}
+
+// WithRowStyleFunc sets a func that selects the style of each normal row
+// based on the values of its cells, e.g. to highlight failed items. The
+// style is applied on top of the alternating row styles configured via
+// WithRowStyles.
+func WithRowStyleFunc(fn RowStyleFunc) Option {
+	return func(t *Table) {
+		t.rowStyleFunc = fn
+	}
+}
+
+// WithCellStyleFunc sets a func that selects the style of each cell of normal
+// rows based on its value, e.g. to highlight values above a threshold. The
+// style is applied on top of the row style.
+func WithCellStyleFunc(fn CellStyleFunc) Option {
+	return func(t *Table) {
+		t.cellStyleFunc = fn
+	}
+}
@@ -0,0 +1,213 @@
+package table
+
+import (
+	"github.com/martinohmann/neat/console"
+	"github.com/martinohmann/neat/internal/util"
+)
+
+// PageSizeTerminal can be passed to WithPageSize to use the terminal height
+// as page size. Tables that are not rendered to a terminal are not split into
+// pages.
+const PageSizeTerminal = -1
+
+// page describes the rows of the table grid that are rendered on a single
+// page.
+type page struct {
+	// header is the number of leading header rows that are repeated at the
+	// top of the page.
+	header int
+	// start and end are the indices of the first and one past the last row
+	// of the page.
+	start int
+	end   int
+}
+
+// RenderPage renders only the page with the given zero-based index to the
+// underlying io.Writer. Returns a *PageRangeError if the page does not exist.
+// See WithPageSize.
+func (t *Table) RenderPage(n int) error {
+	tb, grid, heights := t.layout()
+
+	var pages []page
+	if tb != nil {
+		pages = tb.paginate(grid, heights)
+	}
+
+	if n < 0 || n >= len(pages) {
+		return &PageRangeError{Page: n, Count: len(pages)}
+	}
+
+	tb.writePage(grid, heights, pages[n], n == len(pages)-1)
+
+	_, err := tb.render()
+	return err
+}
+
+// PageCount returns the number of pages the table is split into when it is
+// rendered. Returns 1 if pagination is disabled and 0 if the table does not
+// have any rows. See WithPageSize.
+func (t *Table) PageCount() int {
+	tb, grid, heights := t.layout()
+	if tb == nil {
+		return 0
+	}
+
+	return len(tb.paginate(grid, heights))
+}
+
+// linesPerPage returns the maximum number of lines per page or 0 if the table
+// should not be split into pages.
+func (t *Table) linesPerPage() int {
+	if t.pageSize != PageSizeTerminal {
+		return util.MaxInt(0, t.pageSize)
+	}
+
+	if fw, ok := t.out.(console.FileWriter); ok {
+		return console.TerminalHeight(fw)
+	}
+
+	return 0
+}
+
+// paginate splits the rows of grid into pages. Rows that are tied together by
+// cells spanning multiple rows are never split across pages. A page may
+// exceed the page size if a single row or a block of tied rows does not fit
+// onto it. The caption is not taken into account.
+func (tb *tableBuilder) paginate(grid [][]*renderedCell, heights []int) []page {
+	header := 0
+	for header < len(tb.rows) && tb.rows[header].kind == rowKindHeader {
+		header++
+	}
+
+	size := tb.linesPerPage()
+	if size <= 0 || header == len(tb.rows) {
+		return []page{{end: len(grid)}}
+	}
+
+	// frameLines are the lines of every page that do not belong to normal or
+	// footer rows.
+	frameLines := tb.rowLines(heights, 0, header) + tb.marginTop + tb.marginBottom
+
+	if tb.title != "" || tb.borderMask.Has(BorderTop) {
+		frameLines++
+	}
+
+	if tb.borderMask.Has(BorderBottom) {
+		frameLines++
+	}
+
+	var pages []page
+
+	p := page{header: header, start: header, end: header}
+	lines := frameLines
+
+	for start := header; start < len(grid); {
+		end := tb.blockEnd(grid, start)
+
+		blockLines := tb.rowLines(heights, start, end) + tb.separatorLines(p.end-1, start)
+
+		if p.end > p.start && lines+blockLines > size {
+			pages = append(pages, p)
+
+			p = page{header: header, start: start, end: start}
+			lines = frameLines
+			blockLines = tb.rowLines(heights, start, end) + tb.separatorLines(header-1, start)
+		}
+
+		p.end = end
+		lines += blockLines
+		start = end
+	}
+
+	return append(pages, p)
+}
+
+// blockEnd returns the index one past the last row that is tied to the row
+// at start by cells spanning multiple rows.
+func (tb *tableBuilder) blockEnd(grid [][]*renderedCell, start int) int {
+	end := start + 1
+
+	for i := start; i < end; i++ {
+		for _, rc := range grid[i] {
+			end = util.MaxInt(end, rc.row+rc.rowSpan)
+		}
+	}
+
+	return end
+}
+
+// rowLines returns the number of lines of the rows from start to end
+// including the border lines between them.
+func (tb *tableBuilder) rowLines(heights []int, start, end int) int {
+	lines := util.SumInt(heights[start:end]...)
+
+	for i := start + 1; i < end; i++ {
+		lines += tb.separatorLines(i-1, i)
+	}
+
+	return lines
+}
+
+// separatorLines returns the number of lines of the border line between the
+// rows at index i and j. Returns 0 if i is negative.
+func (tb *tableBuilder) separatorLines(i, j int) int {
+	if i < 0 || tb.separatorBetween(tb.rows[i], tb.rows[j]) == nil {
+		return 0
+	}
+
+	return 1
+}
+
+// writePage writes the rows of p including the repeated header rows and the
+// borders. The caption is only written if last is true.
+func (tb *tableBuilder) writePage(grid [][]*renderedCell, heights []int, p page, last bool) {
+	rows := make([]int, 0, p.header+p.end-p.start)
+
+	for i := 0; i < p.header; i++ {
+		rows = append(rows, i)
+
+		// Header cells are written once per page, so they need to start
+		// from their first line again.
+		for _, rc := range grid[i] {
+			rc.next = 0
+		}
+	}
+
+	for i := p.start; i < p.end; i++ {
+		rows = append(rows, i)
+	}
+
+	first, lastRow := rows[0], rows[len(rows)-1]
+
+	tb.writeMarginLines(tb.marginTop)
+
+	if tb.title != "" {
+		tb.writeTitle(grid[first])
+	} else if tb.borderMask.Has(BorderTop) {
+		tb.writeBorderLine(nil, grid[first], &topBorderLine)
+	}
+
+	for k, i := range rows {
+		tb.writeRowCells(grid[i], heights[i], tb.styles[i])
+
+		if k == len(rows)-1 {
+			break
+		}
+
+		next := rows[k+1]
+
+		if line := tb.separatorBetween(tb.rows[i], tb.rows[next]); line != nil {
+			tb.writeBorderLine(grid[i], grid[next], line)
+		}
+	}
+
+	if tb.borderMask.Has(BorderBottom) {
+		tb.writeBorderLine(grid[lastRow], nil, &bottomBorderLine)
+	}
+
+	if last && tb.caption != "" {
+		tb.writeCaption()
+	}
+
+	tb.writeMarginLines(tb.marginBottom)
+}
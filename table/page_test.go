@@ -0,0 +1,61 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPagedTable(w *bytes.Buffer) *Table {
+	return New(w, WithBorder(BorderStyleASCII), WithPageSize(7), WithCaption("caption")).
+		AddHeader("n", "v").
+		AddRow(0, 0).
+		AddRow(1, 1).
+		AddRow(Cell{Value: "span", RowSpan: 2}, 4).
+		AddRow(9).
+		AddRow(4, 16)
+}
+
+func TestTable_Render_Pages(t *testing.T) {
+	var buf bytes.Buffer
+
+	tbl := newPagedTable(&buf)
+
+	assert.Equal(t, 2, tbl.PageCount())
+	assert.NoError(t, tbl.Render())
+	assert.Equal(t, `+------+----+
+| n    | v  |
++======+====+
+| 0    | 0  |
+| 1    | 1  |
++------+----+
++------+----+
+| n    | v  |
++======+====+
+| span | 4  |
+|      | 9  |
+| 4    | 16 |
++------+----+
+caption      
+`, buf.String())
+}
+
+func TestTable_RenderPage(t *testing.T) {
+	var buf bytes.Buffer
+
+	tbl := newPagedTable(&buf)
+
+	assert.NoError(t, tbl.RenderPage(0))
+	assert.Equal(t, `+------+----+
+| n    | v  |
++======+====+
+| 0    | 0  |
+| 1    | 1  |
++------+----+
+`, buf.String())
+
+	assert.Equal(t, &PageRangeError{Page: 2, Count: 2}, tbl.RenderPage(2))
+	assert.Equal(t, 0, New(&buf).PageCount())
+	assert.Equal(t, 1, New(&buf).AddRow("foo").PageCount())
+}
@@ -0,0 +1,81 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/martinohmann/neat/console"
+	"github.com/martinohmann/neat/internal/util"
+	"github.com/martinohmann/neat/measure"
+	"github.com/martinohmann/neat/text"
+)
+
+// Renderable is a console.Renderable that renders a *Table. It can be used to
+// nest tables into cells of other tables:
+//
+//	inner := table.New(nil).AddRow("pod-1", "Running").AddRow("pod-2", "Pending")
+//	outer := table.New(os.Stdout).AddRow("node-1", inner.Renderable())
+//
+// The io.Writer and the maximum width of the wrapped table are ignored.
+type Renderable struct {
+	t *Table
+}
+
+// Renderable returns a console.Renderable which renders t.
+func (t *Table) Renderable() *Renderable {
+	return &Renderable{t: t}
+}
+
+var _ console.Renderable = (*Renderable)(nil)
+
+// Measure implements console.Renderable. The measurement includes the
+// padding, margin and borders of the table.
+func (r *Renderable) Measure(maxWidth int) measure.Measurement {
+	t := r.table(nil, maxWidth)
+
+	rows := t.arrangeRows()
+	if len(rows) == 0 {
+		return measure.Measurement{}
+	}
+
+	view, rows := t.view(rows)
+	if len(rows) == 0 {
+		return measure.Measurement{}
+	}
+
+	spacingWidth, _ := view.calculateSpacing()
+	requested := measure.Sum(view.measureContent(rows, maxWidth-spacingWidth)...)
+
+	return measure.NewMeasurement(
+		util.MinInt(maxWidth, spacingWidth+requested.Minimum),
+		util.MinInt(maxWidth, spacingWidth+requested.Maximum),
+	)
+}
+
+// Render implements console.Renderable. Lines that are narrower than width
+// are padded with spaces.
+func (r *Renderable) Render(width int) string {
+	var buf bytes.Buffer
+
+	// Errors cannot occur when writing to a *bytes.Buffer.
+	_, _ = r.table(&buf, width).render()
+
+	lines := text.SplitLines(strings.TrimSuffix(buf.String(), "\n"))
+	for i, line := range lines {
+		lines[i] = text.PadRight(line, width)
+	}
+
+	return text.JoinLines(lines)
+}
+
+// table returns a copy of the wrapped table which renders to out using
+// maxWidth. Pagination is disabled for the copy.
+func (r *Renderable) table(out io.Writer, maxWidth int) *Table {
+	t := *r.t
+	t.out = out
+	t.maxWidth = maxWidth
+	t.pageSize = 0
+
+	return &t
+}
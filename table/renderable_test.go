@@ -0,0 +1,31 @@
+package table
+
+import (
+	"io"
+
+	"github.com/martinohmann/neat/measure"
+)
+
+func (s *Suite) TestTable_Render_Nested() {
+	inner := New(nil, WithBorder(BorderStyleASCII)).
+		AddRow("pod-1", "Running").
+		AddRow("pod-2", "Pending")
+
+	s.Equal(measure.NewMeasurement(19, 19), inner.Renderable().Measure(80))
+	s.Equal(measure.NewMeasurement(10, 10), inner.Renderable().Measure(10))
+
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorderMask(BorderColumn)).
+				AddRow("node-1", inner.Renderable()).
+				AddRow("node-2", "none")
+		},
+		`
+node-1.│.+-------+---------+
+.......│.|.pod-1.|.Running.|
+.......│.|.pod-2.|.Pending.|
+.......│.+-------+---------+
+node-2.│.none...............
+`,
+	)
+}
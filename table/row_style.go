@@ -0,0 +1,107 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/martinohmann/neat/style"
+)
+
+// RowStyleFunc returns the style for a normal table row based on the values
+// of its cells. Values of grid slots covered by cells spanning multiple
+// columns or rows are nil. Must return nil if the row should not be styled.
+type RowStyleFunc func(values []interface{}) *style.Style
+
+// CellStyleFunc returns the style for a cell of a normal table row based on
+// its value and the index of its column. Must return nil if the cell should
+// not be styled.
+type CellStyleFunc func(value interface{}, colIdx int) *style.Style
+
+// rowStyle returns the style of row. The style of normal rows is made of the
+// configured alternating row style that is selected by stripe and the style
+// returned by the RowStyleFunc if set. Header and footer rows use the header
+// and footer style. Returns nil if the row should not be styled.
+func (t *Table) rowStyle(row *tableRow, stripe int) *style.Style {
+	if row.source != nil {
+		if row.columns == nil {
+			// In expanded layout all rows of a record are styled alike.
+			stripe = row.group
+		}
+
+		row = row.source
+	}
+
+	switch row.kind {
+	case rowKindHeader:
+		return t.headerStyle
+	case rowKindFooter:
+		return t.footerStyle
+	}
+
+	var s *style.Style
+
+	if len(t.rowStyles) > 0 {
+		s = mergeStyles(s, t.rowStyles[stripe%len(t.rowStyles)])
+	}
+
+	if t.rowStyleFunc != nil {
+		s = mergeStyles(s, t.rowStyleFunc(row.values()))
+	}
+
+	return s
+}
+
+// cellStyle returns the style of the cell at colIdx of row on top of the row
+// style rs. Returns nil if the cell should not be styled.
+func (t *Table) cellStyle(row *tableRow, colIdx int, rs *style.Style) *style.Style {
+	switch {
+	case row.columns != nil:
+		// Cells of rows with hidden columns are styled based on the source
+		// column they display. The hidden column marker is not styled.
+		if row.columns[colIdx] < 0 {
+			return rs
+		}
+
+		row, colIdx = row.source, row.columns[colIdx]
+	case row.source != nil:
+		// In expanded layout only the value in the last column is styled.
+		if colIdx != len(row.cells)-1 || row.sourceCol < 0 {
+			return rs
+		}
+
+		row, colIdx = row.source, row.sourceCol
+	}
+
+	if row.kind != rowKindNormal || t.cellStyleFunc == nil {
+		return rs
+	}
+
+	return mergeStyles(rs, t.cellStyleFunc(row.cells[colIdx].value, colIdx))
+}
+
+// mergeStyles returns a style with the attributes of b applied on top of the
+// attributes of a. Either of them may be nil.
+func mergeStyles(a, b *style.Style) *style.Style {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	default:
+		return a.NewWith(b)
+	}
+}
+
+// styleLine applies s to line using r. Since line may already contain styled
+// text, s is restored after each reset sequence so that it covers the whole
+// line.
+func styleLine(r *style.Renderer, s *style.Style, line string) string {
+	if s == nil {
+		return line
+	}
+
+	if reset := r.ResetString(); reset != "" {
+		line = strings.ReplaceAll(line, reset, reset+r.EscapeString(s))
+	}
+
+	return r.Sprint(s, line)
+}
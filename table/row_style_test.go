@@ -0,0 +1,91 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/martinohmann/neat/style"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Render_RowStyles(t *testing.T) {
+	defer style.Enable()()
+
+	var buf bytes.Buffer
+
+	err := New(&buf,
+		WithRowStyles(nil, style.New(style.BgBlue)),
+		WithRowStyleFunc(func(values []interface{}) *style.Style {
+			if values[1] == "Failed" {
+				return style.New(style.FgRed)
+			}
+			return nil
+		}),
+		WithCellStyleFunc(func(value interface{}, colIdx int) *style.Style {
+			if n, ok := value.(int); ok && n > 10 {
+				return style.New(style.FgYellow)
+			}
+			return nil
+		})).
+		AddHeader("name", "status", "restarts").
+		AddRow("foo", "Running", 0).
+		AddRow("bar", "Failed", 42).
+		AddRow("baz", "Running", 1).
+		Render()
+
+	assert.NoError(t, err)
+
+	lines := strings.Split(buf.String(), "\n")
+
+	assert.Equal(t, "name status  restarts", lines[0])
+	assert.Equal(t, "foo  Running 0       ", lines[1])
+	assert.Equal(t,
+		"\x1b[44;31mbar \x1b[0m\x1b[44;31m \x1b[0m\x1b[44;31mFailed \x1b[0m\x1b[44;31m \x1b[0m\x1b[44;31;33m42      \x1b[0m",
+		lines[2],
+	)
+	assert.Equal(t, "baz  Running 1       ", lines[3])
+}
+
+func TestTable_Render_RowStylesHiddenColumns(t *testing.T) {
+	var buf bytes.Buffer
+
+	var rowValues [][]interface{}
+	var cellCols []int
+
+	err := New(&buf,
+		WithRenderer(style.NewProfileRenderer(style.ProfileANSI)),
+		WithMaxWidth(9),
+		WithColumnPriority(2, 0, 1),
+		WithHiddenColumnMarker("+"),
+		WithRowStyleFunc(func(values []interface{}) *style.Style {
+			rowValues = append(rowValues, values)
+			return nil
+		}),
+		WithCellStyleFunc(func(value interface{}, colIdx int) *style.Style {
+			cellCols = append(cellCols, colIdx)
+			if colIdx == 2 {
+				return style.New(style.FgRed)
+			}
+			return nil
+		})).
+		AddRow("foo", "Running", 42).
+		Render()
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{"foo", "Running", 42}}, rowValues)
+	assert.Equal(t, []int{0, 2}, cellCols)
+	assert.Equal(t, "foo \x1b[31m42\x1b[0m +\n", buf.String())
+}
+
+func TestStyleLine(t *testing.T) {
+	defer style.Enable()()
+
+	line := "a " + style.New(style.FgGreen).Sprint("b") + " c"
+
+	assert.Equal(t,
+		"\x1b[44ma \x1b[32mb\x1b[0m\x1b[44m c\x1b[0m",
+		styleLine(style.DefaultRenderer(), style.New(style.BgBlue), line),
+	)
+	assert.Equal(t, line, styleLine(style.DefaultRenderer(), nil, line))
+}
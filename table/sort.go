@@ -0,0 +1,315 @@
+package table
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Comparator compares the values of two table cells. It must return a
+// negative number if a is less than b, zero if a equals b and a positive
+// number if a is greater than b. Values are the values that were originally
+// passed to AddRow. Values of grid slots covered by cells spanning multiple
+// columns or rows are nil.
+type Comparator func(a, b interface{}) int
+
+// SortKey configures the sort order of a table column.
+type SortKey struct {
+	// Column is the index of the column to sort by.
+	Column int
+	// Descending reverses the sort order if true.
+	Descending bool
+	// Compare is used to compare the column values. Defaults to
+	// CompareString if nil.
+	Compare Comparator
+}
+
+// RowFilter decides whether a table row should be rendered or not based on
+// the values of its cells. Must return true if the row should be kept.
+type RowFilter func(values []interface{}) bool
+
+// CompareString compares the plain text of a and b lexicographically.
+func CompareString(a, b interface{}) int {
+	return strings.Compare(valueText(a), valueText(b))
+}
+
+// CompareNatural compares the plain text of a and b using natural sort order,
+// that is, sequences of digits are compared by their numeric value. E.g.
+// "file2" sorts before "file10".
+func CompareNatural(a, b interface{}) int {
+	x, y := valueText(a), valueText(b)
+
+	for len(x) > 0 && len(y) > 0 {
+		if isDigit(x[0]) && isDigit(y[0]) {
+			xd, yd := leadingDigits(x), leadingDigits(y)
+
+			if c := compareDigits(xd, yd); c != 0 {
+				return c
+			}
+
+			x, y = x[len(xd):], y[len(yd):]
+			continue
+		}
+
+		if x[0] != y[0] {
+			return int(x[0]) - int(y[0])
+		}
+
+		x, y = x[1:], y[1:]
+	}
+
+	return len(x) - len(y)
+}
+
+// CompareNumeric compares the plain text of a and b by their numeric value.
+// Numbers sort before values that cannot be parsed as number. Values that are
+// not numeric are compared using CompareString.
+func CompareNumeric(a, b interface{}) int {
+	x, xErr := strconv.ParseFloat(strings.TrimSpace(valueText(a)), 64)
+	y, yErr := strconv.ParseFloat(strings.TrimSpace(valueText(b)), 64)
+
+	switch {
+	case xErr != nil && yErr != nil:
+		return CompareString(a, b)
+	case xErr != nil:
+		return 1
+	case yErr != nil:
+		return -1
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+
+	return s[:end]
+}
+
+// compareDigits compares two strings of digits by their numeric value without
+// parsing them to avoid overflows.
+func compareDigits(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+
+	return strings.Compare(a, b)
+}
+
+// valueText returns the plain text of a cell value.
+func valueText(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+
+	return plainText(v, defaultMaxWidth)
+}
+
+// rowBlock is a sequence of normal rows which are tied together by cells
+// spanning multiple rows. Blocks are sorted, filtered and grouped as a whole
+// based on the values of their first row.
+type rowBlock struct {
+	rows  []*tableRow
+	group int
+}
+
+func (b *rowBlock) values() []interface{} {
+	return b.rows[0].values()
+}
+
+// arrangeRows applies the configured row filter, sort keys and grouping to
+// the normal table rows and appends the footer row computed from column
+// aggregates if configured. The header and footer rows are left untouched. If
+// none of these is configured, the table rows are returned as is.
+func (t *Table) arrangeRows() []*tableRow {
+	if t.rowFilter == nil && len(t.sortKeys) == 0 && !t.groupRows && len(t.aggregates) == 0 {
+		return t.rows
+	}
+
+	start, end := 0, len(t.rows)
+
+	for start < end && t.rows[start].kind == rowKindHeader {
+		start++
+	}
+
+	for end > start && t.rows[end-1].kind == rowKindFooter {
+		end--
+	}
+
+	blocks := t.filterBlocks(makeRowBlocks(t.rows[start:end]))
+
+	t.sortBlocks(blocks)
+
+	if t.groupRows {
+		blocks = t.groupBlocks(blocks)
+	}
+
+	rows := make([]*tableRow, 0, len(t.rows))
+	rows = append(rows, t.rows[:start]...)
+
+	for _, block := range blocks {
+		for i, row := range block.rows {
+			// Cells of the last block may span beyond the last normal row.
+			// They are cut off so that they do not extend into the rows
+			// following the block after rearranging.
+			cells := clipRowSpans(row.cells, len(block.rows)-i)
+
+			rows = append(rows, &tableRow{kind: row.kind, cells: cells, group: block.group})
+		}
+	}
+
+	if len(t.aggregates) > 0 && t.numCols > 0 {
+		rows = append(rows, t.aggregateRow(rows))
+	}
+
+	return append(rows, t.rows[end:]...)
+}
+
+// clipRowSpans returns cells with the row spans limited to maxRows. cells is
+// returned as is if no row span exceeds maxRows.
+func clipRowSpans(cells []*tableCell, maxRows int) []*tableCell {
+	var clipped []*tableCell
+
+	for i, cell := range cells {
+		if cell == nil || cell.rowSpan <= maxRows {
+			continue
+		}
+
+		if clipped == nil {
+			clipped = make([]*tableCell, len(cells))
+			copy(clipped, cells)
+		}
+
+		c := *cell
+		c.rowSpan = maxRows
+		clipped[i] = &c
+	}
+
+	if clipped == nil {
+		return cells
+	}
+
+	return clipped
+}
+
+// makeRowBlocks splits rows into blocks of rows which are tied together by
+// cells spanning multiple rows.
+func makeRowBlocks(rows []*tableRow) []*rowBlock {
+	var blocks []*rowBlock
+
+	coveredUntil := -1
+
+	for i, row := range rows {
+		if i > coveredUntil {
+			blocks = append(blocks, &rowBlock{})
+		}
+
+		block := blocks[len(blocks)-1]
+		block.rows = append(block.rows, row)
+
+		for _, cell := range row.cells {
+			if cell != nil && i+cell.rowSpan-1 > coveredUntil {
+				coveredUntil = i + cell.rowSpan - 1
+			}
+		}
+	}
+
+	return blocks
+}
+
+func (t *Table) filterBlocks(blocks []*rowBlock) []*rowBlock {
+	if t.rowFilter == nil {
+		return blocks
+	}
+
+	filtered := blocks[:0]
+
+	for _, block := range blocks {
+		if t.rowFilter(block.values()) {
+			filtered = append(filtered, block)
+		}
+	}
+
+	return filtered
+}
+
+func (t *Table) sortBlocks(blocks []*rowBlock) {
+	if len(t.sortKeys) == 0 {
+		return
+	}
+
+	values := make(map[*rowBlock][]interface{}, len(blocks))
+
+	for _, block := range blocks {
+		values[block] = block.values()
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		a, b := values[blocks[i]], values[blocks[j]]
+
+		for _, key := range t.sortKeys {
+			if key.Column < 0 || key.Column >= t.numCols {
+				continue
+			}
+
+			compare := key.Compare
+			if compare == nil {
+				compare = CompareString
+			}
+
+			c := compare(a[key.Column], b[key.Column])
+			if c == 0 {
+				continue
+			}
+
+			if key.Descending {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return false
+	})
+}
+
+// groupBlocks groups blocks by the text of the group column. Groups are
+// ordered by the first appearance of their value.
+func (t *Table) groupBlocks(blocks []*rowBlock) []*rowBlock {
+	groups := make(map[string]int)
+
+	for _, block := range blocks {
+		var key string
+
+		if t.groupColumn >= 0 && t.groupColumn < t.numCols {
+			key = valueText(block.values()[t.groupColumn])
+		}
+
+		group, ok := groups[key]
+		if !ok {
+			group = len(groups)
+			groups[key] = group
+		}
+
+		block.group = group
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].group < blocks[j].group
+	})
+
+	return blocks
+}
@@ -0,0 +1,82 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareNatural(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(CompareNatural("file2", "file10") < 0)
+	assert.True(CompareNatural("file10", "file2") > 0)
+	assert.True(CompareNatural("file002", "file2") == 0)
+	assert.True(CompareNatural("a", "b") < 0)
+	assert.True(CompareNatural("foo", "foobar") < 0)
+	assert.True(CompareNatural(99999999999999999, 100000000000000000) < 0)
+	assert.True(CompareNatural(nil, "a") < 0)
+}
+
+func TestCompareNumeric(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(CompareNumeric(2, 10) < 0)
+	assert.True(CompareNumeric("10.5", 2) > 0)
+	assert.True(CompareNumeric(" 3 ", 3.0) == 0)
+	assert.True(CompareNumeric(1000, "n/a") < 0)
+	assert.True(CompareNumeric("n/a", -1) > 0)
+	assert.True(CompareNumeric("a", "b") < 0)
+}
+
+func TestTable_Render_FilterAndSort(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := New(&buf,
+		WithSortKeys(
+			SortKey{Column: 1, Compare: CompareNumeric, Descending: true},
+			SortKey{Column: 0, Compare: CompareNatural},
+		),
+		WithRowFilter(func(values []interface{}) bool {
+			return values[0] != "skip"
+		})).
+		AddHeader("name", "size").
+		AddRow("node10", 3).
+		AddRow("skip", 100).
+		AddRow("node2", 3).
+		AddRow("node1", 20).
+		AddFooter("total", 126).
+		Render()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "name   size\nnode1  20  \nnode2  3   \nnode10 3   \ntotal  126 \n", buf.String())
+}
+
+func (s *Suite) TestTable_Render_GroupBy() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w,
+				WithBorderMask(BorderSection|BorderColumn),
+				WithSortKeys(SortKey{Column: 1}),
+				WithGroupBy(0)).
+				AddHeader("zone", "node").
+				AddRow("b", "node-4").
+				AddRow("a", "node-3").
+				AddRow("b", "node-2").
+				AddRow(Cell{Value: "a", RowSpan: 2}, "node-1").
+				AddRow("node-5")
+		},
+		`
+zone │ node..
+═════╪═══════
+a....│ node-1
+.....│ node-5
+a....│ node-3
+═════╪═══════
+b....│ node-2
+b....│ node-4
+`,
+	)
+}
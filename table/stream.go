@@ -0,0 +1,292 @@
+package table
+
+import (
+	"io"
+
+	"github.com/martinohmann/neat/measure"
+)
+
+// Stream renders table rows to an io.Writer as soon as they are added instead
+// of keeping all rows in memory until the table is rendered. This allows
+// rendering tables with an unbounded number of rows, e.g. when tailing a log.
+//
+// Since rows are written immediately, the column widths have to be known in
+// advance. They are measured once from the header rows and the first sampled
+// normal rows (see WithStreamSampleSize). If all columns have fixed widths
+// configured via WithColumnWidth, rows are written immediately without
+// sampling. Cells of later rows that do not fit into the column widths are
+// truncated or wrapped.
+//
+// Rows that are tied together by cells spanning multiple rows are written
+// once the last covered row was added. Row filters are applied to rows before
+// writing them. Sort keys, grouping, column aggregates, footers and column
+// priorities are not supported by streams.
+type Stream struct {
+	t *Table
+
+	// measures are the column widths. They are nil until enough rows were
+	// sampled.
+	measures []measure.Measurement
+
+	// header contains all header rows for re-emitting them.
+	header []*tableRow
+	// pending contains rows that were added but not written yet.
+	pending []*tableRow
+
+	// last is the last row that was written and lastCells are its rendered
+	// cells. They are needed to draw the border line below it.
+	last      *tableRow
+	lastCells []*renderedCell
+
+	rowsSinceHeader int
+	closed          bool
+
+	// stripe is the index of the next normal row for selecting the
+	// alternating row style.
+	stripe int
+}
+
+// NewStream creates a new *Stream which writes table rows to the provided
+// io.Writer using opts. See the documentation of Stream for the options that
+// are supported.
+func NewStream(out io.Writer, opts ...Option) *Stream {
+	return &Stream{t: New(out, opts...)}
+}
+
+// AddHeader adds a header row to the stream. Header rows must be added before
+// any normal row and are re-emitted according to the configured header
+// interval (see WithStreamHeaderInterval). Returns an error if the row cannot
+// be added. See Table.TryAddRow for the possible errors.
+func (s *Stream) AddHeader(columns ...interface{}) error {
+	return s.add(rowKindHeader, columns)
+}
+
+// AddRow adds a normal row to the stream. The row is written to the
+// underlying io.Writer immediately once the column widths are known. Returns
+// an error if the row cannot be added or writing it failed. See
+// Table.TryAddRow for the possible errors.
+func (s *Stream) AddRow(columns ...interface{}) error {
+	return s.add(rowKindNormal, columns)
+}
+
+// Flush writes all pending rows, even if the configured number of rows for
+// sampling the column widths was not reached yet. Rows that are still covered
+// by cells spanning multiple rows stay pending.
+func (s *Stream) Flush() error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+
+	if s.measures == nil {
+		s.measure()
+	}
+
+	if s.spanning() {
+		return nil
+	}
+
+	return s.writePending()
+}
+
+// Close writes all pending rows followed by the bottom border and the table
+// caption if configured. Rows cannot be added after the stream was closed.
+func (s *Stream) Close() error {
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	if len(s.pending) > 0 {
+		if s.measures == nil {
+			s.measure()
+		}
+
+		if err := s.writePending(); err != nil {
+			return err
+		}
+	}
+
+	if s.last == nil {
+		return nil
+	}
+
+	tb := newTableBuilder(s.t, nil, s.measures)
+
+	if s.t.borderMask.Has(BorderBottom) {
+		tb.writeBorderLine(s.lastCells, nil, &bottomBorderLine)
+	}
+
+	if s.t.caption != "" {
+		tb.writeCaption()
+	}
+
+	tb.writeMarginLines(s.t.marginBottom)
+
+	_, err := tb.render()
+	return err
+}
+
+func (s *Stream) add(kind rowKind, columns []interface{}) error {
+	if s.closed {
+		return ErrStreamClosed
+	}
+
+	if err := s.t.addRow(kind, columns); err != nil {
+		return err
+	}
+
+	row := s.t.rows[len(s.t.rows)-1]
+
+	// Only keep the last row around for validating the next row.
+	s.t.rows = s.t.rows[len(s.t.rows)-1:]
+
+	if kind == rowKindHeader {
+		s.header = append(s.header, row)
+	}
+
+	s.pending = append(s.pending, row)
+
+	if s.measures == nil {
+		if s.sampled() < s.sampleSize() && !s.fixedWidths() {
+			return nil
+		}
+
+		s.measure()
+	}
+
+	if s.spanning() {
+		return nil
+	}
+
+	return s.writePending()
+}
+
+func (s *Stream) sampleSize() int {
+	if s.t.streamSampleSize <= 0 {
+		return 1
+	}
+
+	return s.t.streamSampleSize
+}
+
+// fixedWidths returns true if all columns have a fixed width. Sampling rows
+// is not necessary in this case.
+func (s *Stream) fixedWidths() bool {
+	for i := 0; i < s.t.numCols; i++ {
+		if s.t.columnWidth(i).Fixed <= 0 {
+			return false
+		}
+	}
+
+	return s.t.numCols > 0
+}
+
+// sampled returns the number of pending normal rows.
+func (s *Stream) sampled() (n int) {
+	for _, row := range s.pending {
+		if row.kind == rowKindNormal {
+			n++
+		}
+	}
+
+	return n
+}
+
+// spanning returns true if the last added row is covered by cells spanning
+// multiple rows.
+func (s *Stream) spanning() bool {
+	for _, n := range s.t.rowSpans {
+		if n > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// measure determines the column widths from the pending rows.
+func (s *Stream) measure() {
+	spacingWidth, _ := s.t.calculateSpacing()
+
+	s.measures = s.t.measureColumns(s.pending, s.t.maxWidth-spacingWidth)
+}
+
+// writePending writes all pending rows to the underlying io.Writer.
+func (s *Stream) writePending() error {
+	var header, normal []*tableRow
+
+	for _, row := range s.pending {
+		if row.kind == rowKindHeader {
+			header = append(header, row)
+		} else {
+			normal = append(normal, row)
+		}
+	}
+
+	s.pending = nil
+
+	tb := newTableBuilder(s.t, nil, s.measures)
+	tb.stripe = s.stripe
+
+	if len(header) > 0 {
+		s.writeRows(tb, header)
+	}
+
+	for _, block := range s.t.filterBlocks(makeRowBlocks(normal)) {
+		interval := s.t.streamHeaderInterval
+
+		// Blocks of rows tied together by cells spanning multiple rows are
+		// not split, so the header is re-emitted before a block that would
+		// exceed the interval.
+		if interval > 0 && s.rowsSinceHeader > 0 && s.rowsSinceHeader+len(block.rows) > interval && len(s.header) > 0 {
+			s.writeRows(tb, s.header)
+		}
+
+		s.writeRows(tb, block.rows)
+	}
+
+	s.stripe = tb.stripe
+
+	_, err := tb.render()
+	return err
+}
+
+// writeRows lays out rows and writes them to tb including the border line
+// that separates them from the previously written row.
+func (s *Stream) writeRows(tb *tableBuilder, rows []*tableRow) {
+	tb.rows = rows
+
+	grid, heights := tb.layoutRows()
+
+	if s.last == nil {
+		tb.writeMarginLines(s.t.marginTop)
+	}
+
+	switch {
+	case s.last != nil:
+		if line := tb.separatorBetween(s.last, rows[0]); line != nil {
+			tb.writeBorderLine(s.lastCells, grid[0], line)
+		}
+	case s.t.title != "":
+		tb.writeTitle(grid[0])
+	case s.t.borderMask.Has(BorderTop):
+		tb.writeBorderLine(nil, grid[0], &topBorderLine)
+	}
+
+	for i, cells := range grid {
+		tb.writeRowCells(cells, heights[i], tb.styles[i])
+
+		if line := tb.separatorAfter(i); line != nil {
+			tb.writeBorderLine(cells, grid[i+1], line)
+		}
+	}
+
+	if rows[0].kind == rowKindHeader {
+		s.rowsSinceHeader = 0
+	} else {
+		s.rowsSinceHeader += len(rows)
+	}
+
+	s.last, s.lastCells = rows[len(rows)-1], grid[len(grid)-1]
+}
@@ -0,0 +1,109 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewStream(&buf,
+		WithBorder(BorderStyleASCII),
+		WithStreamSampleSize(2),
+		WithStreamHeaderInterval(3))
+
+	assert.NoError(t, s.AddHeader("time", "message"))
+	assert.NoError(t, s.AddRow("10:00", "started"))
+	assert.Equal(t, "", buf.String(), "rows must not be written before the sample is complete")
+
+	assert.NoError(t, s.AddRow("10:01", "ok"))
+	assert.Equal(t, `+-------+---------+
+| time  | message |
++=======+=========+
+| 10:00 | started |
+| 10:01 | ok      |
+`, buf.String())
+
+	buf.Reset()
+
+	assert.NoError(t, s.AddRow(Cell{Value: "10:02", RowSpan: 2}, "first"))
+	assert.Equal(t, "", buf.String(), "rows covered by row spans must be written together")
+
+	assert.NoError(t, s.AddRow("a very long message"))
+	assert.NoError(t, s.AddRow("10:03", "done"))
+	assert.NoError(t, s.Close())
+
+	// The header is re-emitted before the block that would exceed the
+	// interval.
+	assert.Equal(t, `+=======+=========+
+| time  | message |
++=======+=========+
+| 10:02 | first   |
+|       | a very… |
+| 10:03 | done    |
++-------+---------+
+`, buf.String())
+
+	assert.Equal(t, ErrStreamClosed, s.AddRow("10:04", "too late"))
+}
+
+func TestStream_FixedWidths(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewStream(&buf, WithColumnWidth(ColumnWidth{Fixed: 3}, ColumnWidth{Fixed: 5}))
+
+	assert.NoError(t, s.AddRow("a", "b"))
+	assert.Equal(t, "a   b    \n", buf.String())
+
+	assert.NoError(t, s.AddRow("abcdef", "b"))
+	assert.Error(t, s.AddHeader("c", "d"))
+	assert.NoError(t, s.Close())
+
+	assert.Equal(t, "a   b    \nab… b    \n", buf.String())
+}
+
+func TestStream_FixedWidthsSkipSampling(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewStream(&buf,
+		WithStreamSampleSize(10),
+		WithColumnWidth(ColumnWidth{Fixed: 3}, ColumnWidth{Fixed: 5}))
+
+	assert.NoError(t, s.AddHeader("h", "i"))
+	assert.Equal(t, "h   i    \n", buf.String())
+
+	assert.NoError(t, s.AddRow("a", "b"))
+	assert.Equal(t, "h   i    \na   b    \n", buf.String())
+	assert.NoError(t, s.Close())
+
+	buf.Reset()
+
+	s = NewStream(&buf,
+		WithStreamSampleSize(10),
+		WithColumnWidth(ColumnWidth{Fixed: 3}, ColumnWidth{Min: 5}))
+
+	assert.NoError(t, s.AddRow("a", "b"))
+	assert.Equal(t, "", buf.String(), "rows must be sampled unless all columns have fixed widths")
+	assert.NoError(t, s.Close())
+	assert.Equal(t, "a   b    \n", buf.String())
+}
+
+func TestStream_HeaderIntervalRowBlocks(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewStream(&buf, WithStreamHeaderInterval(3))
+
+	assert.NoError(t, s.AddHeader("h", "i"))
+	assert.NoError(t, s.AddRow("a", "1"))
+	assert.NoError(t, s.AddRow("b", "2"))
+	assert.NoError(t, s.AddRow(Cell{Value: "c", RowSpan: 2}, "3"))
+	assert.NoError(t, s.AddRow("4"))
+	assert.NoError(t, s.AddRow("d", "5"))
+	assert.NoError(t, s.AddRow("e", "6"))
+	assert.NoError(t, s.Close())
+
+	assert.Equal(t, "h i\na 1\nb 2\nh i\nc 3\n  4\nd 5\nh i\ne 6\n", buf.String())
+}
@@ -12,19 +12,44 @@ import (
 	runewidth "github.com/mattn/go-runewidth"
 )
 
-const defaultMaxWidth = 80
+const (
+	defaultMaxWidth = 80
+
+	// ellipsis is displayed in the last line of cells that were cut off
+	// because they exceed the maximum row height.
+	ellipsis = text.Ellipsis
+)
 
 // Table can render properly aligned columns and rows of information.
 type Table struct {
 	out      io.Writer
-	padding  int
 	margin   int
 	maxWidth int
 
+	// cell padding
+	paddingLeft   int
+	paddingRight  int
+	paddingTop    int
+	paddingBottom int
+
+	// vertical table margin
+	marginTop    int
+	marginBottom int
+
 	borderMask  BorderMask
 	borderRunes BorderRunes
 	borderStyle *style.Style
 
+	title            string
+	titleAlignment   text.Alignment
+	titleStyle       *style.Style
+	caption          string
+	captionAlignment text.Alignment
+	captionStyle     *style.Style
+
+	// renderer creates the escape sequences for all styles.
+	renderer *style.Renderer
+
 	// global cell attributes
 	alignment text.Alignment
 	style     *style.Style
@@ -34,21 +59,67 @@ type Table struct {
 	columnAlignment []text.Alignment
 	columnStyle     []*style.Style
 	columnWordWrap  []bool
+	columnWidths    []ColumnWidth
+
+	// conditional styles
+	headerStyle   *style.Style
+	footerStyle   *style.Style
+	rowStyles     []*style.Style
+	rowStyleFunc  RowStyleFunc
+	cellStyleFunc CellStyleFunc
+
+	// column hiding on narrow terminals
+	columnPriorities   []int
+	hiddenColumnMarker string
+
+	// vertical alignment and row height constraints
+	verticalAlignment       text.VerticalAlignment
+	columnVerticalAlignment []text.VerticalAlignment
+	minRowHeight            int
+	maxRowHeight            int
+
+	padShortRows     bool
+	truncateLongRows bool
+
+	// row arrangement
+	sortKeys    []SortKey
+	rowFilter   RowFilter
+	groupRows   bool
+	groupColumn int
+
+	rowLayout Layout
 
-	// rows and cols contain pointers to exactly the same cells, only in
-	// different orientation. This helps to simplify calculation of column
-	// widths and row heights.
+	// pageSize is the number of lines per page, see WithPageSize
+	pageSize int
+
+	// streaming configuration, see Stream
+	streamSampleSize     int
+	streamHeaderInterval int
+
+	// aggregates for the automatically generated footer row
+	aggregates []*Aggregate
+
+	// rows contains all table rows in the order they were added. Sorting,
+	// filtering and grouping is only applied during rendering.
 	rows []*tableRow
-	cols []*tableCol
+	// numCols is the number of table columns. It is determined by the first
+	// row added to the table.
+	numCols int
+
+	// rowSpans contains the number of upcoming rows that are still covered
+	// by cells spanning multiple rows for each column. spanCells contains
+	// the covering cells.
+	rowSpans  []int
+	spanCells []*tableCell
 }
 
 // New creates a new *Table which will be rendered to the provided io.Writer
 // using opts.
 func New(out io.Writer, opts ...Option) *Table {
 	t := &Table{
-		out:     out,
-		padding: 1,
-		margin:  0,
+		out:          out,
+		paddingLeft:  1,
+		paddingRight: 1,
 	}
 
 	t.applyOptions(opts)
@@ -61,12 +132,33 @@ func (t *Table) applyOptions(opts []Option) {
 		option(t)
 	}
 
-	if t.padding < 0 {
-		t.padding = 0
+	for _, v := range []*int{
+		&t.paddingLeft, &t.paddingRight, &t.paddingTop, &t.paddingBottom,
+		&t.margin, &t.marginTop, &t.marginBottom,
+	} {
+		*v = util.MaxInt(0, *v)
 	}
 
-	if t.margin < 0 {
-		t.margin = 0
+	if t.renderer == nil {
+		if t.out != nil {
+			t.renderer = style.RendererFor(t.out)
+		} else {
+			t.renderer = style.DefaultRenderer()
+		}
+	}
+
+	// Styles that are not explicitly configured are taken from the active
+	// theme.
+	for v, name := range map[**style.Style]string{
+		&t.borderStyle:  "table.border",
+		&t.titleStyle:   "table.title",
+		&t.captionStyle: "table.caption",
+		&t.headerStyle:  "table.header",
+		&t.footerStyle:  "table.footer",
+	} {
+		if *v == nil {
+			*v = style.ThemeStyle(name)
+		}
 	}
 
 	if t.maxWidth <= 0 {
@@ -80,6 +172,9 @@ func (t *Table) applyOptions(opts []Option) {
 	if t.borderRunes == nil {
 		t.borderRunes = DefaultBorderRunes
 	} else {
+		// Copy the runes to not alter the map passed to WithBorderRunes.
+		t.borderRunes = t.borderRunes.copy()
+
 		for k := range DefaultBorderRunes {
 			if _, ok := t.borderRunes[k]; !ok {
 				t.borderRunes[k] = DefaultBorderRunes[k]
@@ -88,117 +183,240 @@ func (t *Table) applyOptions(opts []Option) {
 	}
 }
 
-// mustValidate validates that cols is of the same length as previously added
-// table rows and that the rowKind is allowed to add. Panics if validation
-// failed.
-func (t *Table) mustValidate(kind rowKind, cols []interface{}) {
+// validate validates that cells is of the same length as previously added
+// table rows, that cells spanning multiple columns do not overlap with cells
+// spanning multiple rows and that the rowKind is allowed to add. Returns an
+// error if validation failed.
+func (t *Table) validate(kind rowKind, cells []*tableCell) error {
 	if len(t.rows) == 0 {
-		return
+		return nil
 	}
 
-	if len(cols) != len(t.rows[0].cells) {
-		panic(fmt.Sprintf("expected %d columns, got %d", len(t.rows[0].cells), len(cols)))
+	if len(cells) != len(t.rows[0].cells) {
+		covered := 0
+		for i := range cells {
+			if i < len(t.rowSpans) && t.rowSpans[i] > 0 {
+				covered++
+			}
+		}
+
+		return &ColumnMismatchError{Expected: len(t.rows[0].cells), Actual: len(cells), Covered: covered}
+	}
+
+	for i, cell := range cells {
+		if cell == nil {
+			continue
+		}
+
+		for j := i + 1; j < i+cell.colSpan; j++ {
+			if t.rowSpans[j] > 0 {
+				return &SpanOverlapError{Column: i, SpanColumn: j}
+			}
+		}
 	}
 
 	prevRow := t.rows[len(t.rows)-1]
 
 	if prevRow.kind > kind {
-		panic(fmt.Sprintf("cannot add %s row after %s row", kind, prevRow.kind))
+		return &RowOrderError{Kind: kind.String(), PrevKind: prevRow.kind.String()}
 	}
+
+	return nil
 }
 
 // Reset resets the table by clearing all rows. This is useful for creating
 // multiple tables with the same options.
 func (t *Table) Reset() *Table {
 	t.rows = nil
-	t.cols = nil
+	t.numCols = 0
+	t.rowSpans = nil
+	t.spanCells = nil
 	return t
 }
 
 // AddRow adds a row to the table. Panics if the number of columns does not
 // align with the number of columns of already existing table rows or if AddRow
-// is called after rows were added via AddFooter.
+// is called after rows were added via AddFooter. Use TryAddRow to obtain an
+// error instead.
 //
 // Columns implementing console.Renderable are NOT formatted using the cell and
 // column specific options (e.g. style, alignment, word wrap) configured via
 // the table.With* and table.WithColumn* option funcs. This allows users to add
 // custom cell behaviour if needed.
+//
+// Columns of type Cell or *Cell can span multiple columns and rows. See the
+// documentation of Cell for details.
 func (t *Table) AddRow(columns ...interface{}) *Table {
-	return t.addRow(rowKindNormal, columns)
+	return t.mustAddRow(rowKindNormal, columns)
 }
 
 // AddHeader adds a header row to the table. Panics if the number of columns
 // does not align with the number of columns of already existing table rows or
-// if AddHeader is called after rows were added via AddRow or AddFooter.
+// if AddHeader is called after rows were added via AddRow or AddFooter. Use
+// TryAddHeader to obtain an error instead.
 //
 // Columns implementing console.Renderable are NOT formatted using the cell and
 // column specific options (e.g. style, alignment, word wrap) configured via
 // the table.With* and table.WithColumn* option funcs. This allows users to add
 // custom cell behaviour if needed.
 func (t *Table) AddHeader(columns ...interface{}) *Table {
-	return t.addRow(rowKindHeader, columns)
+	return t.mustAddRow(rowKindHeader, columns)
 }
 
 // AddFooter adds a footer row to the table. Panics if the number of columns
 // does not align with the number of columns of already existing table rows.
+// Use TryAddFooter to obtain an error instead.
 //
 // Columns implementing console.Renderable are NOT formatted using the cell and
 // column specific options (e.g. style, alignment, word wrap) configured via
 // the table.With* and table.WithColumn* option funcs. This allows users to add
 // custom cell behaviour if needed.
 func (t *Table) AddFooter(columns ...interface{}) *Table {
+	return t.mustAddRow(rowKindFooter, columns)
+}
+
+// TryAddRow works like AddRow but returns an error instead of panicking. The
+// error is of type *ColumnMismatchError, *RowOrderError or *SpanOverlapError.
+// The table is not altered if an error is returned.
+func (t *Table) TryAddRow(columns ...interface{}) error {
+	return t.addRow(rowKindNormal, columns)
+}
+
+// TryAddHeader works like AddHeader but returns an error instead of
+// panicking. See TryAddRow for the possible errors.
+func (t *Table) TryAddHeader(columns ...interface{}) error {
+	return t.addRow(rowKindHeader, columns)
+}
+
+// TryAddFooter works like AddFooter but returns an error instead of
+// panicking. See TryAddRow for the possible errors.
+func (t *Table) TryAddFooter(columns ...interface{}) error {
 	return t.addRow(rowKindFooter, columns)
 }
 
-func (t *Table) addRow(kind rowKind, columns []interface{}) *Table {
-	t.mustValidate(kind, columns)
+func (t *Table) mustAddRow(kind rowKind, columns []interface{}) *Table {
+	if err := t.addRow(kind, columns); err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func (t *Table) addRow(kind rowKind, columns []interface{}) error {
+	// Rows of different kinds are arranged separately, so cells spanning
+	// multiple rows are cut off at the last row of their kind.
+	rowSpans := t.rowSpans
+	newKind := len(t.rows) > 0 && t.rows[len(t.rows)-1].kind != kind
+	if newKind {
+		t.rowSpans = make([]int, len(rowSpans))
+	}
+
+	cells := t.fitCells(t.makeCells(columns))
+
+	if err := t.validate(kind, cells); err != nil {
+		t.rowSpans = rowSpans
+		return err
+	}
 
-	cells := t.makeCells(columns)
+	if newKind {
+		t.cutRowSpans(rowSpans)
+	}
 
 	t.rows = append(t.rows, &tableRow{kind: kind, cells: cells})
 
-	if t.cols == nil {
-		t.cols = make([]*tableCol, len(cells))
+	if t.numCols == 0 {
+		t.numCols = len(cells)
+		t.rowSpans = make([]int, len(cells))
+		t.spanCells = make([]*tableCell, len(cells))
 	}
 
-	for i, cell := range cells {
-		if t.cols[i] == nil {
-			t.cols[i] = &tableCol{}
+	for i := 0; i < len(cells); {
+		cell := cells[i]
+		if cell == nil {
+			// Slot is covered by a cell of a previous row.
+			t.rowSpans[i]--
+			i++
+			continue
 		}
 
-		t.cols[i].cells = append(t.cols[i].cells, cell)
+		for j := i; j < i+cell.colSpan; j++ {
+			t.rowSpans[j] = cell.rowSpan - 1
+			t.spanCells[j] = cell
+		}
+
+		i += cell.colSpan
 	}
 
-	return t
+	return nil
 }
 
-// calculateSpacing calculates the width and height occupied by spacing like
-// padding, margin and borders.
-func (t *Table) calculateSpacing() (width, height int) {
-	borderWidth := 0
-	paddingWidth := (len(t.cols) - 1) * t.padding
-	marginWidth := 2 * t.margin
+// cutRowSpans shortens the cells that still cover rowSpans upcoming rows so
+// that they end at the last added row.
+func (t *Table) cutRowSpans(rowSpans []int) {
+	for i := 0; i < len(rowSpans); {
+		if rowSpans[i] <= 0 {
+			i++
+			continue
+		}
 
-	width = marginWidth + paddingWidth
+		cell := t.spanCells[i]
+		cell.rowSpan -= rowSpans[i]
 
-	for _, r := range t.borderRunes {
-		borderWidth = util.MaxInt(borderWidth, runewidth.RuneWidth(r))
+		i += cell.colSpan
 	}
+}
 
-	if t.borderMask.Has(BorderColumn) {
-		// If we have vertical borders we need to have double the padding: left
-		// and right from the border.
-		width += paddingWidth + ((len(t.cols) - 1) * borderWidth)
+// fitCells pads or truncates cells to the number of table columns if enabled
+// via WithPadShortRows or WithTruncateLongRows.
+func (t *Table) fitCells(cells []*tableCell) []*tableCell {
+	if len(t.rows) == 0 {
+		return cells
 	}
 
+	numCols := len(t.rows[0].cells)
+
+	if t.padShortRows {
+		for len(cells) < numCols {
+			if t.rowSpans[len(cells)] > 0 {
+				cells = append(cells, nil)
+			} else {
+				cells = append(cells, t.makeCell("", len(cells)))
+			}
+		}
+	}
+
+	if t.truncateLongRows && len(cells) > numCols {
+		cells = cells[:numCols]
+
+		// Shrink cells that span beyond the last table column.
+		for i, cell := range cells {
+			if cell != nil && i+cell.colSpan > numCols {
+				cell.colSpan = numCols - i
+			}
+		}
+	}
+
+	return cells
+}
+
+// calculateSpacing calculates the width and height occupied by spacing like
+// padding, margin and borders.
+func (t *Table) calculateSpacing() (width, height int) {
+	borderWidth := t.borderWidth()
+	marginWidth := 2 * t.margin
+
+	width = marginWidth + (t.numCols-1)*t.columnGap()
+
 	if t.borderMask.Has(BorderLeft) {
-		width += t.padding + borderWidth
+		width += t.paddingLeft + borderWidth
 	}
 
 	if t.borderMask.Has(BorderRight) {
-		width += t.padding + borderWidth
+		width += t.paddingRight + borderWidth
 	}
 
+	height = t.marginTop + t.marginBottom
+
 	if t.borderMask.Has(BorderTop) {
 		height++
 	}
@@ -214,6 +432,27 @@ func (t *Table) calculateSpacing() (width, height int) {
 	return width, height
 }
 
+// columnGap returns the width of the space between two adjacent columns. If
+// we have vertical borders we need to have the right padding of the left cell
+// and the left padding of the right cell around the border. Otherwise the
+// paddings collapse and the larger one of both is used.
+func (t *Table) columnGap() int {
+	if t.borderMask.Has(BorderColumn) {
+		return t.paddingRight + t.paddingLeft + t.borderWidth()
+	}
+
+	return util.MaxInt(t.paddingLeft, t.paddingRight)
+}
+
+// borderWidth returns the maximum display width of all border runes.
+func (t *Table) borderWidth() (width int) {
+	for _, r := range t.borderRunes {
+		width = util.MaxInt(width, runewidth.RuneWidth(r))
+	}
+
+	return width
+}
+
 // Render renders the table to the underlying io.Writer. Returns the number of
 // lines rendered and an error if rendering failed.
 func (t *Table) RenderLines() (int, error) {
@@ -228,83 +467,64 @@ func (t *Table) Render() error {
 }
 
 func (t *Table) render() (int, error) {
-	if len(t.cols) == 0 {
+	tb, grid, heights := t.layout()
+	if tb == nil {
 		return 0, nil
 	}
 
-	spacingWidth, spacingHeight := t.calculateSpacing()
-	availWidth := t.maxWidth - spacingWidth
-
-	measures := t.measureColumns(availWidth)
-	columnWidths := measure.Sum(measures...)
-
-	totalWidth := spacingWidth + columnWidths.Maximum
-
-	tb := newTableBuilder(t, measures)
-
-	tb.Grow((len(t.rows) + spacingHeight) * (totalWidth + 1))
+	pages := tb.paginate(grid, heights)
 
-	if t.borderMask.Has(BorderTop) {
-		tb.writeBorderLine(BorderRuneCornerTopLeft, BorderRuneCornerTopRight,
-			BorderRuneHorizontal, BorderRuneIntersectionTop)
+	for i, p := range pages {
+		tb.writePage(grid, heights, p, i == len(pages)-1)
 	}
 
-	for i, row := range t.rows {
-		cellLines, maxCellHeight := renderRowCells(row, measures)
-
-		if maxCellHeight > 1 {
-			// Grow buffer to have enough space to hold all lines of the table
-			// row without the need to reallocate between writing the lines of
-			// the row.
-			tb.Grow((maxCellHeight - 1) * (totalWidth + 1))
-		}
-
-		tb.writeRowCells(cellLines, maxCellHeight)
-
-		if i >= len(t.rows)-1 {
-			continue
-		}
+	return tb.render()
+}
 
-		if t.borderMask.Has(BorderSection) && (row.kind != rowKindNormal || row.kind != t.rows[i+1].kind) {
-			tb.writeBorderLine(BorderRuneSectionIntersectionLeft, BorderRuneSectionIntersectionRight,
-				BorderRuneSectionHorizontal, BorderRuneSectionIntersectionCenter)
-		} else if t.borderMask.Has(BorderRow) {
-			tb.writeBorderLine(BorderRuneIntersectionLeft, BorderRuneIntersectionRight,
-				BorderRuneHorizontal, BorderRuneIntersectionCenter)
-		}
+// layout arranges and measures the table rows and renders their cells onto
+// a grid. Returns a nil *tableBuilder if there are no rows to render.
+func (t *Table) layout() (*tableBuilder, [][]*renderedCell, []int) {
+	rows := t.arrangeRows()
+	if len(rows) == 0 {
+		return nil, nil, nil
 	}
 
-	if t.borderMask.Has(BorderBottom) {
-		tb.writeBorderLine(BorderRuneCornerBottomLeft, BorderRuneCornerBottomRight,
-			BorderRuneHorizontal, BorderRuneIntersectionBottom)
+	// If columns need to be hidden or the rows are displayed in expanded
+	// layout, the rest of the table is rendered using a view of the table.
+	view, rows := t.view(rows)
+	if len(rows) == 0 {
+		// The expanded layout drops header rows, so a table that only has
+		// header rows has nothing to render.
+		return nil, nil, nil
 	}
 
-	return tb.render()
-}
+	spacingWidth, spacingHeight := view.calculateSpacing()
+	availWidth := view.maxWidth - spacingWidth
 
-// renderRowCells renders all cells of the row to produce the lines that we
-// need to calculate the row height. Returns a slice of slices of lines for
-// each column in the row and the maximum column height.
-func renderRowCells(row *tableRow, measures []measure.Measurement) (cellLines [][]string, maxHeight int) {
-	cellLines = make([][]string, len(row.cells))
+	measures := view.measureColumns(rows, availWidth)
+	columnWidths := measure.Sum(measures...)
 
-	for colIdx, cell := range row.cells {
-		rendered := cell.Render(measures[colIdx].Maximum)
-		lines := text.SplitLines(rendered)
+	totalWidth := spacingWidth + columnWidths.Maximum
 
-		maxHeight = util.MaxInt(maxHeight, len(lines))
+	tb := newTableBuilder(view, rows, measures)
 
-		cellLines[colIdx] = lines
-	}
+	grid, heights := tb.layoutRows()
 
-	return cellLines, maxHeight
+	// Grow buffer to have enough space to hold all lines of the table
+	// without the need to reallocate while writing.
+	tb.Grow((util.SumInt(heights...) + spacingHeight) * (totalWidth + 1))
+
+	return tb, grid, heights
 }
 
-func (t *Table) measureColumns(availWidth int) []measure.Measurement {
-	measures := make([]measure.Measurement, len(t.cols))
+// measureColumns measures the columns of rows and fits them into availWidth.
+func (t *Table) measureColumns(rows []*tableRow, availWidth int) []measure.Measurement {
+	measures := t.measureContent(rows, availWidth)
 
-	for i, col := range t.cols {
-		measures[i] = col.measure(availWidth)
+	// Flexible columns share the width that is left after allocating all
+	// other columns.
+	if t.flexColumns(measures, availWidth) {
+		return measures
 	}
 
 	requested := measure.Sum(measures...)
@@ -324,15 +544,88 @@ func (t *Table) measureColumns(availWidth int) []measure.Measurement {
 	return t.truncateColumns(measures, availWidth)
 }
 
+// measureContent measures the contents of the columns of rows and applies the
+// configured column width constraints.
+func (t *Table) measureContent(rows []*tableRow, availWidth int) []measure.Measurement {
+	measures := make([]measure.Measurement, t.numCols)
+
+	for _, row := range rows {
+		for i, cell := range row.cells {
+			if cell == nil || cell.colSpan > 1 {
+				continue
+			}
+
+			m := cell.Measure(availWidth)
+
+			measures[i] = measure.NewMeasurement(
+				util.MaxInt(measures[i].Minimum, m.Minimum),
+				util.MaxInt(measures[i].Maximum, m.Maximum),
+			)
+		}
+	}
+
+	t.measureSpanningCells(rows, measures, availWidth)
+	t.constrainColumns(measures)
+
+	return measures
+}
+
+// measureSpanningCells widens the columns covered by cells spanning multiple
+// columns if these cells do not fit into the combined width of the columns.
+// The additional width is spread evenly across all covered columns.
+func (t *Table) measureSpanningCells(rows []*tableRow, measures []measure.Measurement, availWidth int) {
+	columnGap := t.columnGap()
+
+	for _, row := range rows {
+		for i, cell := range row.cells {
+			if cell == nil || cell.colSpan == 1 {
+				continue
+			}
+
+			m := cell.Measure(availWidth)
+			spanGap := (cell.colSpan - 1) * columnGap
+
+			spreadWidth(measures[i:i+cell.colSpan], m.Minimum-spanGap, m.Maximum-spanGap)
+		}
+	}
+}
+
+// spreadWidth evenly distributes the width that is missing to satisfy minimum
+// and maximum across measures.
+func spreadWidth(measures []measure.Measurement, minimum, maximum int) {
+	sum := measure.Sum(measures...)
+
+	missingMin := minimum - sum.Minimum
+	missingMax := maximum - sum.Maximum
+
+	for i := range measures {
+		remaining := len(measures) - i
+
+		if missingMin > 0 {
+			share := missingMin / remaining
+			measures[i].Minimum += share
+			missingMin -= share
+		}
+
+		if missingMax > 0 {
+			share := missingMax / remaining
+			measures[i].Maximum += share
+			missingMax -= share
+		}
+
+		measures[i].Maximum = util.MaxInt(measures[i].Minimum, measures[i].Maximum)
+	}
+}
+
 // overflowColumns tries to allocate space for all columns first that request
 // less than the maximum width for each column if the available space is
 // distributed evenly. Columns that are still unallocated after that will receive
 // their requested minimum in the worst case, even if this exceeds the fair
 // column share.
 func (t *Table) overflowColumns(measures []measure.Measurement, availWidth int) []measure.Measurement {
-	fairColWidth := int(float64(availWidth) / float64(len(t.cols)))
+	fairColWidth := int(float64(availWidth) / float64(t.numCols))
 
-	remainingCols := len(t.cols)
+	remainingCols := t.numCols
 	unallocated := make(map[int]struct{})
 
 	for i, m := range measures {
@@ -367,12 +660,35 @@ func (t *Table) overflowColumns(measures []measure.Measurement, availWidth int)
 // truncate columns to less than their requested minimum if there is no other
 // option.
 func (t *Table) truncateColumns(measures []measure.Measurement, availWidth int) []measure.Measurement {
-	fairColWidth := util.MaxInt(0, int(float64(availWidth)/float64(len(t.cols))))
+	remainingCols := t.numCols
+	allocated := make(map[int]struct{})
+
+	// Columns with fixed or minimum width constraints are allocated first so
+	// that they are not starved by other columns.
+	for i, m := range measures {
+		if !t.columnWidth(i).hasMinimum() || m.Minimum > availWidth {
+			continue
+		}
+
+		measures[i].Maximum = m.Minimum
+		availWidth -= m.Minimum
+		remainingCols--
+		allocated[i] = struct{}{}
+	}
+
+	if remainingCols == 0 {
+		return measures
+	}
+
+	fairColWidth := util.MaxInt(0, int(float64(availWidth)/float64(remainingCols)))
 
-	remainingCols := len(t.cols)
 	unallocated := make(map[int]struct{})
 
 	for i, m := range measures {
+		if _, ok := allocated[i]; ok {
+			continue
+		}
+
 		if m.Minimum > fairColWidth {
 			unallocated[i] = struct{}{}
 			continue
@@ -397,16 +713,46 @@ func (t *Table) truncateColumns(measures []measure.Measurement, availWidth int)
 	return measures
 }
 
-func (t *Table) makeCells(cols []interface{}) []console.Renderable {
-	cells := make([]console.Renderable, len(cols))
+// makeCells creates the cells for a new table row and places them onto the
+// table grid. Grid slots that are covered by cells spanning multiple columns
+// or by cells of previous rows spanning multiple rows are left empty.
+func (t *Table) makeCells(cols []interface{}) []*tableCell {
+	cells := make([]*tableCell, 0, len(cols))
 
-	for i, col := range cols {
-		cells[i] = t.makeRenderable(col, i)
+	skipCovered := func() {
+		for len(cells) < len(t.rowSpans) && t.rowSpans[len(cells)] > 0 {
+			cells = append(cells, nil)
+		}
 	}
 
+	for _, col := range cols {
+		skipCovered()
+
+		cell := t.makeCell(col, len(cells))
+
+		cells = append(cells, cell)
+
+		for i := 1; i < cell.colSpan; i++ {
+			cells = append(cells, nil)
+		}
+	}
+
+	skipCovered()
+
 	return cells
 }
 
+func (t *Table) makeCell(v interface{}, colIdx int) *tableCell {
+	switch c := v.(type) {
+	case Cell:
+		return newTableCell(t.makeCellRenderable(&c, colIdx), c.Value, c.ColSpan, c.RowSpan)
+	case *Cell:
+		return newTableCell(t.makeCellRenderable(c, colIdx), c.Value, c.ColSpan, c.RowSpan)
+	default:
+		return newTableCell(t.makeRenderable(v, colIdx), v, 1, 1)
+	}
+}
+
 func (t *Table) makeRenderable(v interface{}, colIdx int) console.Renderable {
 	if r, ok := v.(console.Renderable); ok {
 		return r
@@ -415,12 +761,42 @@ func (t *Table) makeRenderable(v interface{}, colIdx int) console.Renderable {
 	return t.makeTextRenderable(v, colIdx)
 }
 
-func (t *Table) makeTextRenderable(v interface{}, colIdx int) console.Renderable {
+// makeCellRenderable creates the console.Renderable for c and applies the
+// cell specific overrides.
+func (t *Table) makeCellRenderable(c *Cell, colIdx int) console.Renderable {
+	r, ok := c.Value.(console.Renderable)
+	if !ok {
+		tr := t.makeTextRenderable(c.Value, colIdx)
+
+		if c.Alignment != nil {
+			tr.Alignment = *c.Alignment
+		}
+
+		if c.Style != nil {
+			tr.Style = c.Style
+		}
+
+		if c.WordWrap != nil {
+			tr.WordWrap = *c.WordWrap
+		}
+
+		r = tr
+	}
+
+	if c.Padding > 0 {
+		r = paddedRenderable{Renderable: r, padding: c.Padding}
+	}
+
+	return r
+}
+
+func (t *Table) makeTextRenderable(v interface{}, colIdx int) text.Text {
 	r := text.Text{
 		Alignment: t.alignment,
 		Style:     t.style,
 		Text:      fmt.Sprint(v),
 		WordWrap:  t.wordWrap,
+		Renderer:  t.renderer,
 	}
 
 	if colIdx < len(t.columnAlignment) {
@@ -438,6 +814,16 @@ func (t *Table) makeTextRenderable(v interface{}, colIdx int) console.Renderable
 	return r
 }
 
+// cellVerticalAlignment returns the vertical alignment for cells in the column
+// at colIdx.
+func (t *Table) cellVerticalAlignment(colIdx int) text.VerticalAlignment {
+	if colIdx < len(t.columnVerticalAlignment) {
+		return t.columnVerticalAlignment[colIdx]
+	}
+
+	return t.verticalAlignment
+}
+
 type rowKind int
 
 func (k rowKind) String() string {
@@ -458,25 +844,35 @@ const (
 )
 
 type tableRow struct {
-	kind  rowKind
-	cells []console.Renderable
-}
-
-type tableCol struct {
-	cells []console.Renderable
+	kind rowKind
+	// cells contains one entry per table column. Entries for grid slots
+	// which are covered by cells spanning multiple columns or rows are nil.
+	cells []*tableCell
+	// group is the index of the row group the row belongs to if rows are
+	// grouped via WithGroupBy.
+	group int
+
+	// source is the row that this row was created from if rows are displayed
+	// in expanded layout or columns are hidden. sourceCol is the index of the
+	// source column displayed in an expanded row.
+	source    *tableRow
+	sourceCol int
+	// columns maps the cells of a row with hidden columns to the index of
+	// their source column. Entries are -1 for cells which do not display a
+	// source column, e.g. the hidden column marker. Nil for expanded rows.
+	columns []int
 }
 
-func (c *tableCol) measure(maxWidth int) measure.Measurement {
-	var m measure.Measurement
-
-	for _, cell := range c.cells {
-		cm := cell.Measure(maxWidth)
+// values returns the values of the cells of r. Values of grid slots covered by
+// cells spanning multiple columns or rows are nil.
+func (r *tableRow) values() []interface{} {
+	values := make([]interface{}, len(r.cells))
 
-		m = measure.NewMeasurement(
-			util.MaxInt(m.Minimum, cm.Minimum),
-			util.MaxInt(m.Maximum, cm.Maximum),
-		)
+	for i, cell := range r.cells {
+		if cell != nil {
+			values[i] = cell.value
+		}
 	}
 
-	return m
+	return values
 }
@@ -1,37 +1,343 @@
 package table
 
 import (
-	"fmt"
+	"io"
 	"strings"
 
+	"github.com/acarl005/stripansi"
+	"github.com/martinohmann/neat/internal/util"
 	"github.com/martinohmann/neat/measure"
+	"github.com/martinohmann/neat/style"
 	"github.com/martinohmann/neat/text"
 )
 
+// borderLine describes the runes used to draw a horizontal border line.
+// Junction runes that are left unset default to BorderRuneHorizontal.
+type borderLine struct {
+	left       BorderRune
+	right      BorderRune
+	horizontal BorderRune
+
+	// junction is drawn if the column borders above and below the line
+	// meet, junctionUp and junctionDown if there is only a column border
+	// above or below the line respectively.
+	junction     BorderRune
+	junctionUp   BorderRune
+	junctionDown BorderRune
+
+	// crossLeft and crossRight are drawn if a cell spanning multiple rows
+	// crosses the line left or right of the junction.
+	crossLeft  BorderRune
+	crossRight BorderRune
+}
+
+var (
+	topBorderLine = borderLine{
+		left:         BorderRuneCornerTopLeft,
+		right:        BorderRuneCornerTopRight,
+		horizontal:   BorderRuneHorizontal,
+		junctionDown: BorderRuneIntersectionTop,
+	}
+
+	bottomBorderLine = borderLine{
+		left:       BorderRuneCornerBottomLeft,
+		right:      BorderRuneCornerBottomRight,
+		horizontal: BorderRuneHorizontal,
+		junctionUp: BorderRuneIntersectionBottom,
+	}
+
+	rowBorderLine = borderLine{
+		left:         BorderRuneIntersectionLeft,
+		right:        BorderRuneIntersectionRight,
+		horizontal:   BorderRuneHorizontal,
+		junction:     BorderRuneIntersectionCenter,
+		junctionUp:   BorderRuneIntersectionBottom,
+		junctionDown: BorderRuneIntersectionTop,
+		crossLeft:    BorderRuneIntersectionLeft,
+		crossRight:   BorderRuneIntersectionRight,
+	}
+
+	sectionBorderLine = borderLine{
+		left:         BorderRuneSectionIntersectionLeft,
+		right:        BorderRuneSectionIntersectionRight,
+		horizontal:   BorderRuneSectionHorizontal,
+		junction:     BorderRuneSectionIntersectionCenter,
+		junctionUp:   BorderRuneSectionIntersectionBottom,
+		junctionDown: BorderRuneSectionIntersectionTop,
+		crossLeft:    BorderRuneSectionIntersectionLeft,
+		crossRight:   BorderRuneSectionIntersectionRight,
+	}
+)
+
+// renderedCell holds the rendered lines of a table cell and its position on
+// the table grid.
+type renderedCell struct {
+	lines   []string
+	width   int
+	row     int
+	col     int
+	rowSpan int
+	colSpan int
+
+	// next is the index of the next line that should be written.
+	next int
+
+	// style is applied to each line of the cell.
+	style *style.Style
+}
+
 // tableBuilder builds the string representation of a table and writes it to
 // the underlying io.Writer of the wrapped *Table.
 type tableBuilder struct {
 	strings.Builder
 	*Table
 
-	measures      []measure.Measurement
-	marginSpaces  string
-	paddingSpaces string
-	lines         int
+	// rows are the table rows to render after sorting, filtering and
+	// grouping was applied. Shadows the rows of the embedded *Table.
+	rows               []*tableRow
+	measures           []measure.Measurement
+	marginSpaces       string
+	paddingLeftSpaces  string
+	paddingRightSpaces string
+	lines              int
+
+	// styles contains the style of each row. It is populated by layoutRows.
+	styles []*style.Style
+	// stripe is the index of the next normal row for selecting the
+	// alternating row style.
+	stripe int
 }
 
-func newTableBuilder(t *Table, measures []measure.Measurement) *tableBuilder {
+func newTableBuilder(t *Table, rows []*tableRow, measures []measure.Measurement) *tableBuilder {
 	return &tableBuilder{
-		Table:         t,
-		measures:      measures,
-		marginSpaces:  text.Spaces(t.margin),
-		paddingSpaces: text.Spaces(t.padding),
+		Table:              t,
+		rows:               rows,
+		measures:           measures,
+		marginSpaces:       text.Spaces(t.margin),
+		paddingLeftSpaces:  text.Spaces(t.paddingLeft),
+		paddingRightSpaces: text.Spaces(t.paddingRight),
 	}
 }
 
+// layoutRows renders all table cells and places them onto a grid. Grid slots
+// covered by cells spanning multiple columns or rows all point to the same
+// *renderedCell. Returns the grid and the height of each row.
+func (tb *tableBuilder) layoutRows() (grid [][]*renderedCell, heights []int) {
+	grid = make([][]*renderedCell, len(tb.rows))
+	heights = make([]int, len(tb.rows))
+
+	for i := range grid {
+		grid[i] = make([]*renderedCell, len(tb.measures))
+	}
+
+	var cells, rowSpanning []*renderedCell
+
+	tb.styles = make([]*style.Style, len(tb.rows))
+
+	for i, row := range tb.rows {
+		tb.styles[i] = tb.rowStyle(row, tb.stripe)
+
+		if row.kind == rowKindNormal {
+			tb.stripe++
+		}
+
+		for j, cell := range row.cells {
+			if cell == nil {
+				continue
+			}
+
+			rc := &renderedCell{
+				row:     i,
+				col:     j,
+				rowSpan: util.MinInt(cell.rowSpan, len(tb.rows)-i),
+				colSpan: cell.colSpan,
+				width:   tb.spanWidth(j, cell.colSpan),
+				style:   tb.cellStyle(row, j, tb.styles[i]),
+			}
+
+			rc.lines = text.SplitLines(cell.Render(rc.width))
+
+			for k := i; k < i+rc.rowSpan; k++ {
+				for l := j; l < j+rc.colSpan; l++ {
+					grid[k][l] = rc
+				}
+			}
+
+			cells = append(cells, rc)
+
+			if rc.rowSpan > 1 {
+				rowSpanning = append(rowSpanning, rc)
+				continue
+			}
+
+			heights[i] = util.MaxInt(heights[i], len(rc.lines))
+		}
+
+		// Rows that only consist of slots covered by cells of previous rows
+		// should still occupy at least one line.
+		heights[i] = tb.constrainHeight(util.MaxInt(heights[i], 1)) + tb.verticalPadding()
+	}
+
+	// Cells spanning multiple rows may need more lines than the rows they
+	// span provide. In this case the last row they cover is enlarged.
+	for _, rc := range rowSpanning {
+		if missing := len(rc.lines) + tb.verticalPadding() - tb.spanHeight(rc, heights); missing > 0 {
+			lastRow := rc.row + rc.rowSpan - 1
+			heights[lastRow] = tb.constrainHeight(heights[lastRow]-tb.verticalPadding()+missing) + tb.verticalPadding()
+		}
+	}
+
+	for _, rc := range cells {
+		rc.lines = tb.padLines(rc, tb.alignLines(rc, tb.spanHeight(rc, heights)-tb.verticalPadding()))
+
+		for i, line := range rc.lines {
+			rc.lines[i] = styleLine(tb.renderer, rc.style, line)
+		}
+	}
+
+	return grid, heights
+}
+
+// verticalPadding returns the number of blank lines above and below the
+// content of each cell.
+func (tb *tableBuilder) verticalPadding() int {
+	return tb.paddingTop + tb.paddingBottom
+}
+
+// constrainHeight constrains a row height to the configured minimum and
+// maximum row heights. Row heights do not include the vertical padding.
+func (tb *tableBuilder) constrainHeight(height int) int {
+	height = util.MaxInt(height, tb.minRowHeight)
+
+	if tb.maxRowHeight > 0 {
+		height = util.MinInt(height, tb.maxRowHeight)
+	}
+
+	return height
+}
+
+// alignLines fits the lines of rc into height. Excess lines are cut off and
+// replaced with an ellipsis line, missing lines are filled with blank lines
+// according to the vertical alignment of the column.
+func (tb *tableBuilder) alignLines(rc *renderedCell, height int) []string {
+	lines := rc.lines
+
+	if len(lines) > height {
+		lines = lines[:height]
+
+		if height > 0 {
+			lines[height-1] = text.Truncate(text.PadRight(ellipsis, rc.width), rc.width)
+		}
+
+		return lines
+	}
+
+	padding := height - len(lines)
+	if padding == 0 {
+		return lines
+	}
+
+	var top int
+
+	switch tb.cellVerticalAlignment(rc.col) {
+	case text.AlignMiddle:
+		top = padding / 2
+	case text.AlignBottom:
+		top = padding
+	}
+
+	blank := text.Spaces(rc.width)
+	aligned := make([]string, 0, height)
+
+	for i := 0; i < top; i++ {
+		aligned = append(aligned, blank)
+	}
+
+	aligned = append(aligned, lines...)
+
+	for len(aligned) < height {
+		aligned = append(aligned, blank)
+	}
+
+	return aligned
+}
+
+// padLines surrounds the aligned lines of rc with the blank lines of the top
+// and bottom padding.
+func (tb *tableBuilder) padLines(rc *renderedCell, lines []string) []string {
+	if tb.verticalPadding() == 0 {
+		return lines
+	}
+
+	blank := text.Spaces(rc.width)
+	padded := make([]string, 0, len(lines)+tb.verticalPadding())
+
+	for i := 0; i < tb.paddingTop; i++ {
+		padded = append(padded, blank)
+	}
+
+	padded = append(padded, lines...)
+
+	for i := 0; i < tb.paddingBottom; i++ {
+		padded = append(padded, blank)
+	}
+
+	return padded
+}
+
+// spanWidth returns the width available to a cell starting at column colIdx
+// which spans colSpan columns.
+func (tb *tableBuilder) spanWidth(colIdx, colSpan int) int {
+	width := (colSpan - 1) * tb.columnGap()
+
+	for _, m := range tb.measures[colIdx : colIdx+colSpan] {
+		width += m.Maximum
+	}
+
+	return width
+}
+
+// spanHeight returns the number of lines available to rc, including the
+// border lines between the rows it spans.
+func (tb *tableBuilder) spanHeight(rc *renderedCell, heights []int) int {
+	height := util.SumInt(heights[rc.row : rc.row+rc.rowSpan]...)
+
+	for i := rc.row; i < rc.row+rc.rowSpan-1; i++ {
+		if tb.separatorAfter(i) != nil {
+			height++
+		}
+	}
+
+	return height
+}
+
+// separatorAfter returns the border line that should be drawn between the row
+// at index i and its successor. Returns nil if no border line should be drawn.
+func (tb *tableBuilder) separatorAfter(i int) *borderLine {
+	if i >= len(tb.rows)-1 {
+		return nil
+	}
+
+	return tb.separatorBetween(tb.rows[i], tb.rows[i+1])
+}
+
+// separatorBetween returns the border line that should be drawn between row
+// and next. Returns nil if no border line should be drawn.
+func (tb *tableBuilder) separatorBetween(row, next *tableRow) *borderLine {
+	if tb.borderMask.Has(BorderSection) && (row.kind != rowKindNormal || row.kind != next.kind || row.group != next.group) {
+		return &sectionBorderLine
+	} else if tb.borderMask.Has(BorderHeader) && row.kind == rowKindHeader && next.kind != rowKindHeader {
+		return &sectionBorderLine
+	} else if tb.borderMask.Has(BorderRow) {
+		return &rowBorderLine
+	}
+
+	return nil
+}
+
 func (tb *tableBuilder) writeBorderString(s string) {
 	if tb.borderStyle != nil {
-		s = tb.borderStyle.Sprint(s)
+		s = tb.renderer.Sprint(tb.borderStyle, s)
 	}
 
 	tb.WriteString(s)
@@ -45,46 +351,82 @@ func (tb *tableBuilder) writeBorderRune(r BorderRune) {
 	tb.writeBorderString(string(tb.borderRunes[r]))
 }
 
-func (tb *tableBuilder) writePaddingSpaces() { tb.WriteString(tb.paddingSpaces) }
-
 func (tb *tableBuilder) writeMarginSpaces() { tb.WriteString(tb.marginSpaces) }
 
+// writeMarginLines writes n blank lines of the vertical table margin.
+func (tb *tableBuilder) writeMarginLines(n int) {
+	for i := 0; i < n; i++ {
+		tb.writeNewline()
+	}
+}
+
 func (tb *tableBuilder) writeNewline() {
 	tb.WriteRune('\n')
 	tb.lines++
 }
 
-func (tb *tableBuilder) writeRowCells(cellLines [][]string, maxCellHeight int) {
+// writeCellLine writes the next line of rc.
+func (tb *tableBuilder) writeCellLine(rc *renderedCell) {
+	if rc.next < len(rc.lines) {
+		tb.WriteString(rc.lines[rc.next])
+	} else {
+		tb.WriteString(text.Spaces(rc.width))
+	}
+
+	rc.next++
+}
+
+// writeBorderPadding writes n horizontal border runes for the padding next to
+// a column border. If blank is true, spaces are written instead.
+func (tb *tableBuilder) writeBorderPadding(horizontal BorderRune, n int, blank bool) {
+	if blank {
+		tb.WriteString(text.Spaces(n))
+	} else {
+		tb.writeBorderRuneN(horizontal, n)
+	}
+}
+
+// writeRowCells writes the lines of cells. The padding between the cells is
+// styled using the row style rs so that background colors cover the whole
+// row.
+func (tb *tableBuilder) writeRowCells(cells []*renderedCell, height int, rs *style.Style) {
+	paddingLeft := styleLine(tb.renderer, rs, tb.paddingLeftSpaces)
+	paddingRight := styleLine(tb.renderer, rs, tb.paddingRightSpaces)
+	gap := styleLine(tb.renderer, rs, text.Spaces(tb.columnGap()))
+
 	// Write all cells of the current row to the buffer and handle multiple
 	// lines.
-	for lineNum := 0; lineNum < maxCellHeight; lineNum++ {
+	for lineNum := 0; lineNum < height; lineNum++ {
 		tb.writeMarginSpaces()
 
 		if tb.borderMask.Has(BorderLeft) {
 			tb.writeBorderRune(BorderRuneVertical)
-			tb.writePaddingSpaces()
+			tb.WriteString(paddingLeft)
 		}
 
-		for colIdx, lines := range cellLines {
-			if lineNum < len(lines) {
-				tb.WriteString(lines[lineNum])
-			} else {
-				tb.WriteString(text.Spaces(tb.measures[colIdx].Maximum))
-			}
+		for colIdx := 0; colIdx < len(cells); {
+			rc := cells[colIdx]
+
+			tb.writeCellLine(rc)
+
+			colIdx = rc.col + rc.colSpan
 
 			// Insert padding after each column except the last one.
-			if colIdx < len(cellLines)-1 {
-				tb.writePaddingSpaces()
+			if colIdx >= len(cells) {
+				continue
+			}
 
-				if tb.borderMask.Has(BorderColumn) {
-					tb.writeBorderRune(BorderRuneVertical)
-					tb.writePaddingSpaces()
-				}
+			if tb.borderMask.Has(BorderColumn) {
+				tb.WriteString(paddingRight)
+				tb.writeBorderRune(BorderRuneVertical)
+				tb.WriteString(paddingLeft)
+			} else {
+				tb.WriteString(gap)
 			}
 		}
 
 		if tb.borderMask.Has(BorderRight) {
-			tb.writePaddingSpaces()
+			tb.WriteString(paddingRight)
 			tb.writeBorderRune(BorderRuneVertical)
 		}
 
@@ -93,40 +435,112 @@ func (tb *tableBuilder) writeRowCells(cellLines [][]string, maxCellHeight int) {
 	}
 }
 
-func (tb *tableBuilder) writeBorderLine(left, right, horizontal, junction BorderRune) {
+// writeBorderLine writes a horizontal border line between the cells of the
+// rows above and below. Either of them may be nil for the top and bottom
+// borders. Cells spanning multiple rows which cross the border line are
+// continued instead of drawing the line through them.
+func (tb *tableBuilder) writeBorderLine(above, below []*renderedCell, line *borderLine) {
+	crossing := func(colIdx int) *renderedCell {
+		if above != nil && below != nil && above[colIdx] == below[colIdx] {
+			return above[colIdx]
+		}
+
+		return nil
+	}
+
+	lastIdx := len(tb.measures) - 1
+
 	tb.writeMarginSpaces()
 
 	if tb.borderMask.Has(BorderLeft) {
-		tb.writeBorderRune(left)
-		tb.writeBorderRuneN(horizontal, tb.padding)
+		if crossing(0) != nil {
+			tb.writeBorderRune(BorderRuneVertical)
+			tb.WriteString(tb.paddingLeftSpaces)
+		} else {
+			tb.writeBorderRune(line.left)
+			tb.writeBorderRuneN(line.horizontal, tb.paddingLeft)
+		}
 	}
 
-	for i, measure := range tb.measures {
-		line := strings.Repeat(string(tb.borderRunes[horizontal]), measure.Maximum)
+	for colIdx := 0; colIdx <= lastIdx; {
+		if rc := crossing(colIdx); rc != nil {
+			tb.writeCellLine(rc)
+			colIdx = rc.col + rc.colSpan
+		} else {
+			tb.writeBorderRuneN(line.horizontal, tb.measures[colIdx].Maximum)
+			colIdx++
+		}
 
-		tb.writeBorderString(line)
+		if colIdx > lastIdx {
+			break
+		}
 
-		if i < len(tb.measures)-1 {
-			tb.writeBorderRuneN(horizontal, tb.padding)
+		crossLeft := crossing(colIdx-1) != nil
+		crossRight := crossing(colIdx) != nil
 
-			if tb.borderMask.Has(BorderColumn) {
-				tb.writeBorderRune(junction)
-				tb.writeBorderRuneN(horizontal, tb.padding)
-			}
+		if !tb.borderMask.Has(BorderColumn) {
+			tb.writeBorderPadding(line.horizontal, tb.columnGap(), crossLeft || crossRight)
+			continue
 		}
+
+		tb.writeBorderPadding(line.horizontal, tb.paddingRight, crossLeft)
+		tb.writeBorderRune(tb.junctionRune(above, below, colIdx, line, crossLeft, crossRight))
+		tb.writeBorderPadding(line.horizontal, tb.paddingLeft, crossRight)
 	}
 
 	if tb.borderMask.Has(BorderRight) {
-		tb.writeBorderRuneN(horizontal, tb.padding)
-		tb.writeBorderRune(right)
+		if crossing(lastIdx) != nil {
+			tb.WriteString(tb.paddingRightSpaces)
+			tb.writeBorderRune(BorderRuneVertical)
+		} else {
+			tb.writeBorderRuneN(line.horizontal, tb.paddingRight)
+			tb.writeBorderRune(line.right)
+		}
 	}
 
 	tb.writeMarginSpaces()
 	tb.writeNewline()
 }
 
+// junctionRune returns the rune that should be drawn on a border line left of
+// the column at colIdx. The rune depends on whether there are column borders
+// above and below the line and whether adjacent cells cross the line.
+func (tb *tableBuilder) junctionRune(above, below []*renderedCell, colIdx int, line *borderLine, crossLeft, crossRight bool) BorderRune {
+	switch {
+	case crossLeft && crossRight:
+		return BorderRuneVertical
+	case crossLeft:
+		return line.crossLeft
+	case crossRight:
+		return line.crossRight
+	}
+
+	up := above != nil && above[colIdx-1] != above[colIdx]
+	down := below != nil && below[colIdx-1] != below[colIdx]
+
+	switch {
+	case up && down:
+		return line.junction
+	case up:
+		return line.junctionUp
+	case down:
+		return line.junctionDown
+	default:
+		return line.horizontal
+	}
+}
+
+// render writes the table to the underlying io.Writer. If the renderer of the
+// table does not colorize its output, escape sequences of pre-styled cell
+// values are stripped.
 func (tb *tableBuilder) render() (int, error) {
-	_, err := fmt.Fprint(tb.out, tb.String())
+	s := tb.String()
+
+	if !tb.renderer.Enabled() {
+		s = stripansi.Strip(s)
+	}
+
+	_, err := io.WriteString(tb.out, s)
 
 	return tb.lines, err
 }
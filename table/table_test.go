@@ -2,6 +2,7 @@ package table
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -60,6 +61,152 @@ func TestTable_AddRow_PanicOrder(t *testing.T) {
 	New(&buf).AddRow("foo", "bar").AddHeader("baz", "qux")
 }
 
+func TestTable_AddRow_PanicSpanOverlap(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	var buf bytes.Buffer
+	New(&buf).
+		AddRow("foo", Cell{Value: "bar", RowSpan: 2}, "baz").
+		AddRow(Cell{Value: "qux", ColSpan: 2}, "quux")
+}
+
+func TestTable_TryAddRow(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	table := New(&buf)
+
+	assert.NoError(table.TryAddRow("foo", Cell{Value: "bar", RowSpan: 2}, "baz"))
+
+	var mismatchErr *ColumnMismatchError
+	assert.True(errors.As(table.TryAddRow("foo"), &mismatchErr))
+	assert.Equal(&ColumnMismatchError{Expected: 3, Actual: 2, Covered: 1}, mismatchErr)
+	assert.EqualError(mismatchErr, "expected 3 columns, got 2 (1 covered by cells of previous rows)")
+	assert.EqualError(New(nil).AddRow("a", "b").TryAddRow("a"), "expected 2 columns, got 1")
+
+	var overlapErr *SpanOverlapError
+	assert.True(errors.As(table.TryAddRow(Cell{Value: "qux", ColSpan: 2}, "quux"), &overlapErr))
+	assert.Equal(&SpanOverlapError{Column: 0, SpanColumn: 1}, overlapErr)
+
+	assert.NoError(table.TryAddFooter("qux", "", "quux"))
+
+	var orderErr *RowOrderError
+	assert.True(errors.As(table.TryAddHeader("one", "two", "three"), &orderErr))
+	assert.EqualError(orderErr, "cannot add header row after footer row")
+
+	assert.NoError(table.Render())
+	assert.Equal("foo bar baz \nqux     quux\n", buf.String())
+}
+
+func TestTable_AddRow_CutsRowSpansAtKindChange(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+
+	// Overlong body cell followed by a footer.
+	tab := New(&buf).AddRow("x", Cell{Value: "y", RowSpan: 5})
+
+	assert.NoError(tab.TryAddFooter("f", "g"))
+	assert.NoError(tab.Render())
+	assert.Equal("x y\nf g\n", buf.String())
+
+	buf.Reset()
+
+	// Header cell spanning into the body.
+	tab = New(&buf, WithSortKeys(SortKey{Column: 0})).
+		AddHeader("h", Cell{Value: "x", RowSpan: 2})
+
+	var mismatchErr *ColumnMismatchError
+	assert.True(errors.As(tab.TryAddRow("b"), &mismatchErr))
+	assert.Equal(&ColumnMismatchError{Expected: 2, Actual: 1}, mismatchErr)
+
+	assert.NoError(tab.TryAddRow("b", "c"))
+	assert.NoError(tab.TryAddRow("a", "d"))
+	assert.NoError(tab.Render())
+	assert.Equal("h x\na d\nb c\n", buf.String())
+
+	buf.Reset()
+
+	// Body cell spanning into the footer.
+	tab = New(&buf, WithSortKeys(SortKey{Column: 0}), WithRowFilter(func(values []interface{}) bool {
+		return values[0] != "a"
+	})).
+		AddRow("b", "c").
+		AddRow("a", Cell{Value: "y", RowSpan: 2}).
+		AddFooter("f", "g")
+
+	assert.NoError(tab.Render())
+	assert.Equal("b c\nf g\n", buf.String())
+}
+
+func TestTable_Render_VerticalMargin(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+
+	tab := New(&buf, WithVerticalMargin(1, 2), WithCaption("caption")).
+		AddRow("foo", "bar")
+
+	assert.NoError(tab.Render())
+	assert.Equal("\nfoo bar\ncaption\n\n\n", buf.String())
+}
+
+func TestTable_Render_Renderer(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+
+	tab := New(&buf, WithRenderer(style.NewProfileRenderer(style.ProfileANSI)), WithStyle(style.FgHex(0xcd0000))).
+		AddRow("foo")
+
+	assert.NoError(tab.Render())
+	assert.Equal("\x1b[31mfoo\x1b[0m\n", buf.String())
+
+	buf.Reset()
+
+	defer style.Enable()()
+
+	tab = New(&buf).AddRow(style.New(style.Bold).Sprint("foo"))
+
+	// Escape sequences of pre-styled values are stripped if the output is
+	// not colorized.
+	defer style.Disable()()
+
+	assert.NoError(tab.Render())
+	assert.Equal("foo\n", buf.String())
+}
+
+func TestTable_Render_Theme(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+
+	defer style.SetTheme(style.Theme{
+		"table.header": style.New(style.Bold),
+		"table.border": style.New(style.FgBlue),
+	})()
+
+	tab := New(&buf, WithRenderer(style.NewProfileRenderer(style.ProfileANSI)), WithBorderMask(BorderColumn)).
+		AddHeader("a", "b").
+		AddRow("c", "d")
+
+	assert.NoError(tab.Render())
+	assert.Equal("\x1b[1ma\x1b[0m\x1b[1m \x1b[0m\x1b[34m│\x1b[0m\x1b[1m \x1b[0m\x1b[1mb\x1b[0m\n"+
+		"c \x1b[34m│\x1b[0m d\n", buf.String())
+
+	buf.Reset()
+
+	tab = New(&buf, WithRenderer(style.NewProfileRenderer(style.ProfileANSI)), WithHeaderStyle(style.New(style.FgRed))).
+		AddHeader("a")
+
+	assert.NoError(tab.Render())
+	assert.Equal("\x1b[31ma\x1b[0m\n", buf.String())
+}
+
 type Suite struct {
 	suite.Suite
 }
@@ -396,6 +543,352 @@ foo.….baz...
 	)
 }
 
+func (s *Suite) TestTable_Render_FitRows() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithPadShortRows(true), WithTruncateLongRows(true)).
+				AddRow("foo", "bar", "baz").
+				AddRow("one").
+				AddRow(Cell{Value: "two", RowSpan: 2}, "three").
+				AddRow("four", "five", "six").
+				AddRow("seven", Cell{Value: "eight", ColSpan: 3}, "nine")
+		},
+		`
+foo...bar...baz.
+one.............
+two...three.....
+......four..five
+seven.eight.....
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_VerticalAlignment() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w,
+				WithBorderMask(BorderColumn),
+				WithVerticalAlignment(text.AlignBottom),
+				WithColumnVerticalAlignment(text.AlignTop, text.AlignMiddle)).
+				AddRow("one\ntwo\nthree\nfour", "foo", "bar", "baz")
+		},
+		`
+one...│.....│.....│....
+two...│.foo.│.....│....
+three.│.....│.....│....
+four..│.....│.bar.│.baz
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w,
+				WithBorderMask(BorderRow),
+				WithMinRowHeight(2),
+				WithMaxRowHeight(3),
+				WithVerticalAlignment(text.AlignMiddle)).
+				AddRow("one\ntwo\nthree\nfour", "foo").
+				AddRow(Cell{Value: "a\nb\nc\nd\ne\nf\ng\nh", RowSpan: 2}, "bar").
+				AddRow("baz")
+		},
+		`
+one......
+two...foo
+…........
+─────────
+a.....bar
+b........
+c.....───
+d........
+e.....baz
+…........
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_Spans() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w).
+				AddRow(Cell{Value: "foobarbaz", ColSpan: 2}, "qux").
+				AddRow("a", "b", "c")
+		},
+		`
+foobarbaz.qux
+a....b....c..
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorderMask(BorderAll)).
+				AddHeader(Cell{Value: "Name", RowSpan: 2}, Cell{Value: "Network", ColSpan: 2}).
+				AddHeader("RX", "TX").
+				AddRow(Cell{Value: "node-1\nfoo\nbar", RowSpan: 2}, 10, 20).
+				AddRow(30, 40).
+				AddRow("node-2", Cell{Value: "unknown", ColSpan: 2})
+		},
+		`
+┌────────┬─────────┐
+│ Name   │ Network │
+│        ╞════╤════╡
+│        │ RX │ TX │
+╞════════╪════╪════╡
+│ node-1 │ 10 │ 20 │
+│ foo    ├────┼────┤
+│ bar    │ 30 │ 40 │
+├────────┼────┴────┤
+│ node-2 │ unknown │
+└────────┴─────────┘
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorderMask(BorderAll)).
+				AddRow("foo", Cell{Value: "one\ntwo\nthree\nfour", RowSpan: 2}).
+				AddRow("bar")
+		},
+		`
+┌─────┬───────┐
+│ foo │ one   │
+├─────┤ two   │
+│ bar │ three │
+│     │ four  │
+└─────┴───────┘
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorderMask(BorderRow)).
+				AddRow(Cell{Value: "x\ny\nz", RowSpan: 2}, "a", "b").
+				AddRow(Cell{Value: "cd", ColSpan: 2})
+		},
+		`
+x.a.b
+y.───
+z.cd.
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_ColumnWidth() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w,
+				WithMaxWidth(30),
+				WithColumnWidth(ColumnWidth{Flex: 3}, ColumnWidth{Flex: 1}, ColumnWidth{Fixed: 4})).
+				AddRow("description", "ok", "abcdef")
+		},
+		`
+description........ok.....abc…
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithColumnWidth(ColumnWidth{Max: 5}, ColumnWidth{Min: 8})).
+				AddRow("foobarbaz", "x")
+		},
+		`
+foob….x.......
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithMaxWidth(20)).
+				AddRow("a very long description text", "pending-restart")
+		},
+		`
+a.very.l….pending-r…
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithMaxWidth(20), WithColumnWidth(ColumnWidth{}, ColumnWidth{Min: 15})).
+				AddRow("a very long description text", "pending-restart")
+		},
+		`
+a.v….pending-restart
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_ColumnPriority() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithMaxWidth(24), WithColumnPriority(2, 0, 1)).
+				AddHeader("NAME", "NODE", "STATUS").
+				AddRow("nginx-7c5b8d6f4", "worker-node-1", "Running").
+				AddRow(Cell{Value: "spans all", ColSpan: 3})
+		},
+		`
+NAME............STATUS.
+nginx-7c5b8d6f4.Running
+spans.all..............
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithMaxWidth(20), WithColumnPriority(1), WithHiddenColumnMarker(">")).
+				AddHeader("NAME", "NODE", "STATUS").
+				AddRow("nginx-7c5b8d6f4", "worker-node-1", "Running")
+		},
+		`
+NAME............>
+nginx-7c5b8d6f4.>
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_TitleAndCaption() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w,
+				WithBorder(BorderStyleRounded),
+				WithTitle("Pods"),
+				WithCaption("1 pod running"),
+				WithCaptionAlignment(text.AlignRight)).
+				AddHeader("name", "status").
+				AddRow("nginx", "Running")
+		},
+		`
+╭─.Pods.┬─────────╮
+│.name..│.status..│
+╞═══════╪═════════╡
+│.nginx.│.Running.│
+╰───────┴─────────╯
+......1.pod.running
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w,
+				WithBorder(BorderStyleSingle),
+				WithTitle("Pods"),
+				WithTitleAlignment(text.AlignRight)).
+				AddRow("foo", "bar", "baz")
+		},
+		`
+┌─────┬────.Pods.─┐
+│.foo.│.bar.│.baz.│
+└─────┴─────┴─────┘
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithTitle("Pods"), WithTitleAlignment(text.AlignCenter), WithCaption("a long caption that wraps")).
+				AddRow("foo", "bar", "baz")
+		},
+		`
+...Pods....
+foo.bar.baz
+a.long.....
+caption....
+that.wraps.
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_TitleNarrowTable() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorder(BorderStyleASCII), WithTitle("Pods")).
+				AddRow("a")
+		},
+		`
++---+
+|.a.|
++---+
+`,
+	)
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorder(BorderStyleASCII), WithTitle("Pods")).
+				AddRow("abc")
+		},
+		`
++-.….-+
+|.abc.|
++-----+
+`,
+	)
+}
+
+func TestTable_Renderable_TitleNarrowWidth(t *testing.T) {
+	tab := New(nil, WithBorder(BorderStyleASCII), WithTitle("Pods")).AddRow("a")
+
+	for w := 0; w < 8; w++ {
+		assert.NotPanics(t, func() { tab.Renderable().Render(w) }, "width %d", w)
+	}
+}
+
+func (s *Suite) TestTable_Render_CellOverrides() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithColumnAlignment(text.AlignRight), WithMaxWidth(8)).
+				AddRow("foo", "bar").
+				AddRow(Cell{Value: "x", Alignment: Align(text.AlignLeft)}, Cell{Value: "y", Padding: 1}).
+				AddRow(Cell{Value: "ab cd", WordWrap: Wrap(true)}, "z")
+		},
+		`
+.foo.bar
+x.....y.
+..ab.z..
+..cd....
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_CellPadding() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorder(BorderStyleASCII), WithCellPadding(1, 2, 1, 0)).
+				AddHeader("foo", "bar").
+				AddRow("a", "b")
+		},
+		`
++-----+-----+
+|.....|.....|
+|foo..|bar..|
+|.....|.....|
++=====+=====+
+|.....|.....|
+|a....|b....|
+|.....|.....|
++-----+-----+
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_CellPaddingCollapse() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithCellPadding(0, 1, 0, 3)).
+				AddRow("foo", "bar", "baz")
+		},
+		`
+foo...bar...baz
+`,
+	)
+}
+
+func (s *Suite) TestTable_Render_VerticalPaddingRowSpan() {
+	s.testTableRender(
+		func(w io.Writer) *Table {
+			return New(w, WithBorder(BorderStyleASCII), WithCellPadding(1, 1, 0, 1)).
+				AddRow(Cell{Value: "a\nb\nc\nd\ne", RowSpan: 2}, "x").
+				AddRow("y")
+		},
+		`
++---+---+
+|...|...|
+|.a.|.x.|
+|.b.|...|
+|.c.|.y.|
+|.d.|...|
+|.e.|...|
++---+---+
+`,
+	)
+}
+
 func TestMain(t *testing.T) {
 	suite.Run(t, new(Suite))
 }
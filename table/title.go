@@ -0,0 +1,136 @@
+package table
+
+import (
+	"github.com/martinohmann/neat/internal/util"
+	"github.com/martinohmann/neat/measure"
+	"github.com/martinohmann/neat/text"
+)
+
+// innerWidth returns the width of the table without the margins.
+func (tb *tableBuilder) innerWidth() int {
+	spacingWidth, _ := tb.calculateSpacing()
+
+	return spacingWidth - 2*tb.margin + measure.Sum(tb.measures...).Maximum
+}
+
+// writeTitle writes the table title. If the table has a top border, the title
+// is embedded into it. Otherwise it is written on a separate line above the
+// table.
+func (tb *tableBuilder) writeTitle(below []*renderedCell) {
+	if !tb.borderMask.Has(BorderTop) {
+		tb.writeTextLines(text.Text{
+			Text:      tb.title,
+			Alignment: tb.titleAlignment,
+			Style:     tb.titleStyle,
+			Renderer:  tb.renderer,
+		})
+		return
+	}
+
+	runes := tb.topBorderRunes(below)
+
+	start, end := 0, len(runes)
+	if tb.borderMask.Has(BorderLeft) {
+		start++
+	}
+
+	if tb.borderMask.Has(BorderRight) {
+		end--
+	}
+
+	// The title is surrounded by spaces and at least one horizontal border
+	// rune on each side. If there is no space left, the plain top border is
+	// drawn.
+	title := text.Truncate(tb.title, util.MaxInt(0, end-start-4))
+	if title == "" {
+		tb.writeBorderLine(nil, below, &topBorderLine)
+		return
+	}
+
+	width := text.DisplayWidth(title) + 2
+
+	var pos int
+
+	switch tb.titleAlignment {
+	case text.AlignRight:
+		pos = end - 1 - width
+	case text.AlignCenter:
+		pos = start + (end-start-width)/2
+	default:
+		pos = start + 1
+	}
+
+	if tb.titleStyle != nil {
+		title = tb.renderer.Sprint(tb.titleStyle, title)
+	}
+
+	tb.writeMarginSpaces()
+	tb.writeBorderString(string(runes[:pos]))
+	tb.WriteString(" " + title + " ")
+	tb.writeBorderString(string(runes[pos+width:]))
+	tb.writeMarginSpaces()
+	tb.writeNewline()
+}
+
+// writeCaption writes the table caption below the table. Captions that do not
+// fit into the table width are wrapped onto multiple lines.
+func (tb *tableBuilder) writeCaption() {
+	tb.writeTextLines(text.Text{
+		Text:      tb.caption,
+		Alignment: tb.captionAlignment,
+		Style:     tb.captionStyle,
+		WordWrap:  true,
+		Renderer:  tb.renderer,
+	})
+}
+
+// writeTextLines renders t using the table width and writes the resulting
+// lines.
+func (tb *tableBuilder) writeTextLines(t text.Text) {
+	for _, line := range text.SplitLines(t.Render(tb.innerWidth())) {
+		tb.writeMarginSpaces()
+		tb.WriteString(line)
+		tb.writeMarginSpaces()
+		tb.writeNewline()
+	}
+}
+
+// topBorderRunes returns the runes of the top border line above the cells in
+// below without any styling.
+func (tb *tableBuilder) topBorderRunes(below []*renderedCell) []rune {
+	var runes []rune
+
+	appendRuneN := func(r BorderRune, n int) {
+		for i := 0; i < n; i++ {
+			runes = append(runes, tb.borderRunes[r])
+		}
+	}
+
+	line := &topBorderLine
+
+	if tb.borderMask.Has(BorderLeft) {
+		appendRuneN(line.left, 1)
+		appendRuneN(line.horizontal, tb.paddingLeft)
+	}
+
+	for colIdx, m := range tb.measures {
+		if colIdx > 0 {
+			if tb.borderMask.Has(BorderColumn) {
+				appendRuneN(line.horizontal, tb.paddingRight)
+				appendRuneN(tb.junctionRune(nil, below, colIdx, line, false, false), 1)
+				appendRuneN(line.horizontal, tb.paddingLeft)
+			} else {
+				appendRuneN(line.horizontal, tb.columnGap())
+			}
+		}
+
+		appendRuneN(line.horizontal, m.Maximum)
+	}
+
+	if tb.borderMask.Has(BorderRight) {
+		appendRuneN(line.horizontal, tb.paddingRight)
+		appendRuneN(line.right, 1)
+	}
+
+	return runes
+}
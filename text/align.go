@@ -5,9 +5,8 @@ import (
 )
 
 const (
-	space    rune = ' '
-	newline  rune = '\n'
-	ellipsis rune = '…'
+	space   rune = ' '
+	newline rune = '\n'
 )
 
 type Alignment int
@@ -19,6 +18,16 @@ const (
 	AlignJustify
 )
 
+// VerticalAlignment controls the vertical alignment of text within a fixed
+// number of lines.
+type VerticalAlignment int
+
+const (
+	AlignTop VerticalAlignment = iota
+	AlignMiddle
+	AlignBottom
+)
+
 func Align(s string, width int, align Alignment) string {
 	switch align {
 	case AlignRight:
@@ -0,0 +1,164 @@
+package text
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+const (
+	escape          = '\x1b'
+	bell            = '\a'
+	zeroWidthJoiner = '\u200d'
+
+	// reset is the escape sequence that resets all text attributes.
+	reset = "\x1b[0m"
+)
+
+// segment is either an ANSI escape sequence or a grapheme cluster, that is a
+// user-perceived character which may consist of multiple runes.
+type segment struct {
+	text   string
+	width  int
+	escape bool
+}
+
+// isSGR returns true if the segment is an escape sequence which changes text
+// attributes, e.g. colors.
+func (s segment) isSGR() bool {
+	return s.escape && strings.HasPrefix(s.text, "\x1b[") && strings.HasSuffix(s.text, "m")
+}
+
+// isReset returns true if the segment is an escape sequence which resets all
+// text attributes.
+func (s segment) isReset() bool {
+	return s.text == reset || s.text == "\x1b[m"
+}
+
+// segments splits s into escape sequences and grapheme clusters. Grapheme
+// clusters are detected using a simplified set of rules: zero-width runes
+// like combining marks and variation selectors are attached to the preceding
+// rune, runes following a zero-width joiner are attached to it and pairs of
+// regional indicators form a single flag.
+func segments(s string) []segment {
+	segs := make([]segment, 0, len(s))
+
+	for i := 0; i < len(s); {
+		if s[i] == escape {
+			n := escapeLen(s[i:])
+			segs = append(segs, segment{text: s[i : i+n], escape: true})
+			i += n
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(s[i:])
+
+		if last := len(segs) - 1; last >= 0 && !segs[last].escape && joins(segs[last].text, r) {
+			segs[last].text += s[i : i+n]
+		} else {
+			segs = append(segs, segment{text: s[i : i+n], width: runewidth.RuneWidth(r)})
+		}
+
+		i += n
+	}
+
+	return segs
+}
+
+// joins returns true if r belongs to the same grapheme cluster as the
+// preceding cluster.
+func joins(cluster string, r rune) bool {
+	if r == zeroWidthJoiner || (runewidth.RuneWidth(r) == 0 && r >= ' ') {
+		return true
+	}
+
+	last, _ := utf8.DecodeLastRuneInString(cluster)
+	if last == zeroWidthJoiner {
+		return true
+	}
+
+	return isRegionalIndicator(r) && isRegionalIndicator(last) && utf8.RuneCountInString(cluster) == 1
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= '\U0001f1e6' && r <= '\U0001f1ff'
+}
+
+// escapeLen returns the length of the escape sequence at the start of s. CSI
+// sequences end with a final byte in the range 0x40-0x7e, OSC sequences are
+// terminated by BEL or ST. All other escape sequences consist of two bytes.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == bell {
+				return i + 1
+			}
+
+			if s[i] == escape && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		_, n := utf8.DecodeRuneInString(s[1:])
+		return 1 + n
+	}
+
+	return len(s)
+}
+
+// segmentsWidth returns the display width of segs.
+func segmentsWidth(segs []segment) (width int) {
+	for _, seg := range segs {
+		width += seg.width
+	}
+
+	return width
+}
+
+// activeEscapes returns the escape sequences of segs which are still in
+// effect at the end of segs, starting with the last reset sequence if any.
+func activeEscapes(segs []segment) string {
+	var sb strings.Builder
+
+	for _, seg := range segs {
+		if !seg.escape {
+			continue
+		}
+
+		if seg.isReset() {
+			// Escape sequences preceding the reset are not in effect
+			// anymore.
+			sb.Reset()
+		}
+
+		sb.WriteString(seg.text)
+	}
+
+	return sb.String()
+}
+
+// styled returns true if text attributes changed by segs are still in effect
+// at the end of segs.
+func styled(segs []segment) bool {
+	active := false
+
+	for _, seg := range segs {
+		if seg.isSGR() {
+			active = !seg.isReset()
+		}
+	}
+
+	return active
+}
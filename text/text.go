@@ -18,6 +18,12 @@ type Text struct {
 	// WordWrap controls the word wrapping behaviour. If true, words are
 	// wrapped onto multiple lines depending on the desired render width.
 	WordWrap bool
+	// HangingIndent is the number of additional spaces that lines continuing
+	// a wrapped line are indented by. Only used if WordWrap is true.
+	HangingIndent int
+	// Renderer creates the escape sequences for Style. If nil,
+	// style.DefaultRenderer is used.
+	Renderer *style.Renderer
 }
 
 // New creates a new Text.
@@ -46,7 +52,7 @@ func (t Text) Render(width int) string {
 		line = Truncate(line, width)
 
 		if t.Style != nil {
-			line = t.Style.Sprint(line)
+			line = t.renderer().Sprint(t.Style, line)
 		}
 
 		lines[i] = line
@@ -55,9 +61,17 @@ func (t Text) Render(width int) string {
 	return JoinLines(lines)
 }
 
+func (t Text) renderer() *style.Renderer {
+	if t.Renderer != nil {
+		return t.Renderer
+	}
+
+	return style.DefaultRenderer()
+}
+
 func (t Text) maybeWordWrap(width int) string {
 	if t.WordWrap {
-		return WrapWords(t.Text, width)
+		return WrapWordsWith(t.Text, width, WrapOptions{HangingIndent: t.HangingIndent})
 	}
 	return t.Text
 }
@@ -12,8 +12,8 @@ func TestText_Render(t *testing.T) {
 
 	assert.Equal("   ", New("").Render(3))
 	assert.Equal("foo", New("foo").Render(3))
-	assert.Equal("fo"+string(ellipsis), New("foobar").Render(3))
-	assert.Equal("foob"+string(ellipsis), New("foobar").Render(5))
+	assert.Equal("fo"+Ellipsis, New("foobar").Render(3))
+	assert.Equal("foob"+Ellipsis, New("foobar").Render(5))
 	assert.Equal("foobar  ", New("foobar").Render(8))
 }
 
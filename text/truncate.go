@@ -0,0 +1,145 @@
+package text
+
+import "strings"
+
+// Ellipsis is the string that is used by Truncate, TruncateLeft and
+// TruncateMiddle to indicate that text was cut off.
+const Ellipsis = "…"
+
+// Truncate truncates s to a maximum display width by cutting off its end and
+// appending Ellipsis. If the display width of s is not greater than width, it
+// is returned unaltered. A negative width disables truncation. See
+// TruncateWith for details.
+func Truncate(s string, width int) string {
+	return TruncateWith(s, width, Ellipsis)
+}
+
+// TruncateLeft is like Truncate but cuts off the start of s instead.
+func TruncateLeft(s string, width int) string {
+	return TruncateLeftWith(s, width, Ellipsis)
+}
+
+// TruncateMiddle is like Truncate but cuts off the middle of s instead, e.g.
+// "/usr/local/bin" becomes "/usr/…/bin".
+func TruncateMiddle(s string, width int) string {
+	return TruncateMiddleWith(s, width, Ellipsis)
+}
+
+// TruncateWith truncates s to a maximum display width by cutting off its end
+// and appending ellipsis, which may be empty. ANSI escape sequences are never
+// cut and grapheme clusters are never split. If a wide character does not
+// fit, the gap is filled with a space so that the result is exactly width
+// wide. The ellipsis uses the style that is active at the cut and a reset
+// sequence is appended if styling would otherwise leak. For truncate to work
+// as expected s must not contain newlines.
+func TruncateWith(s string, width int, ellipsis string) string {
+	return truncate(s, width, ellipsis, func(avail int) int { return avail })
+}
+
+// TruncateLeftWith is like TruncateWith but cuts off the start of s instead.
+// Escape sequences that are in effect at the cut are preserved.
+func TruncateLeftWith(s string, width int, ellipsis string) string {
+	return truncate(s, width, ellipsis, func(int) int { return 0 })
+}
+
+// TruncateMiddleWith is like TruncateWith but cuts off the middle of s
+// instead. If the remaining width cannot be split evenly, the start of s gets
+// the additional column.
+func TruncateMiddleWith(s string, width int, ellipsis string) string {
+	return truncate(s, width, ellipsis, func(avail int) int { return (avail + 1) / 2 })
+}
+
+// truncate cuts s so that it fits into width including the ellipsis. headFn
+// returns the width of the start of s to keep given the available width, the
+// rest of the available width is taken from the end of s.
+func truncate(s string, width int, ellipsis string, headFn func(avail int) int) string {
+	if width < 0 {
+		return s
+	}
+
+	segs := segments(s)
+	if segmentsWidth(segs) <= width {
+		return s
+	}
+
+	ellipsisSegs := segments(ellipsis)
+	ellipsisWidth := segmentsWidth(ellipsisSegs)
+
+	if ellipsisWidth > width {
+		// There is not even enough space for the ellipsis.
+		ellipsis, ellipsisWidth = "", 0
+	}
+
+	avail := width - ellipsisWidth
+	headWidth := headFn(avail)
+
+	head, headEnd := takeHead(segs, headWidth)
+	tail, tailStart := takeTail(segs[headEnd:], avail-headWidth)
+	tailStart += headEnd
+
+	var sb strings.Builder
+
+	sb.WriteString(head)
+	sb.WriteString(ellipsis)
+
+	if tailStart < len(segs) {
+		// Restore the style that is in effect at the start of the tail.
+		sb.WriteString(activeEscapes(segs[headEnd:tailStart]))
+		sb.WriteString(tail)
+	} else if styled(segs[:headEnd]) {
+		// Do not leak the style of the cut off text.
+		sb.WriteString(reset)
+	}
+
+	return sb.String()
+}
+
+// takeHead returns the leading segments of segs that fit into width and the
+// index of the first segment that was not taken. Escape sequences directly
+// following the taken segments are included.
+func takeHead(segs []segment, width int) (string, int) {
+	var sb strings.Builder
+
+	i := 0
+
+	for ; i < len(segs); i++ {
+		seg := segs[i]
+
+		if seg.width > width {
+			sb.WriteString(Spaces(width))
+			break
+		}
+
+		sb.WriteString(seg.text)
+		width -= seg.width
+	}
+
+	return sb.String(), i
+}
+
+// takeTail returns the trailing segments of segs that fit into width and the
+// index of the first segment that was taken. Escape sequences directly
+// preceding the taken segments are included.
+func takeTail(segs []segment, width int) (string, int) {
+	i := len(segs)
+
+	for ; i > 0; i-- {
+		seg := segs[i-1]
+
+		if seg.width > width {
+			break
+		}
+
+		width -= seg.width
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(Spaces(width))
+
+	for _, seg := range segs[i:] {
+		sb.WriteString(seg.text)
+	}
+
+	return sb.String(), i
+}
@@ -0,0 +1,60 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("foobar", Truncate("foobar", -1))
+	assert.Equal("", Truncate("foobar", 0))
+	assert.Equal("…", Truncate("foobar", 1))
+	assert.Equal("foo…", Truncate("foobar", 4))
+	assert.Equal("foobar", Truncate("foobar", 6))
+	assert.Equal("äö…", Truncate("äöüß", 3))
+	assert.Equal("日 …", Truncate("日本語", 4))
+	assert.Equal("日本…", Truncate("日本語", 5))
+	assert.Equal("éé…", Truncate("éééé", 3))
+	assert.Equal("👩‍👩‍👧…", Truncate("👩‍👩‍👧👩‍👩‍👧", 3))
+}
+
+func TestTruncate_ANSI(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("\x1b[31mfoo…\x1b[0m", Truncate("\x1b[31mfoobar\x1b[0m", 4))
+	assert.Equal("\x1b[31mfo…\x1b[0m", Truncate("\x1b[31mfoo\x1b[0m bar", 3))
+	assert.Equal("\x1b[31mfoo…\x1b[0m", Truncate("\x1b[31mfoobar", 4))
+	assert.Equal("foo\x1b[31m…\x1b[0m", Truncate("foo\x1b[31mbar\x1b[0m", 4))
+	assert.Equal("\x1b]8;;http://x\afoo…", Truncate("\x1b]8;;http://x\afoobar", 4))
+}
+
+func TestTruncateLeft(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("foobar", TruncateLeft("foobar", 6))
+	assert.Equal("…bar", TruncateLeft("foobar", 4))
+	assert.Equal("… 語", TruncateLeft("日本語", 4))
+	assert.Equal("\x1b[31m…bar\x1b[0m", TruncateLeft("\x1b[31mfoobar\x1b[0m", 4))
+	assert.Equal("\x1b[31m…\x1b[0m\x1b[32mbar\x1b[0m", TruncateLeft("\x1b[31mfoo\x1b[0m\x1b[32mbar\x1b[0m", 4))
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("/usr/…/bin", TruncateMiddle("/usr/local/sbin/bin", 10))
+	assert.Equal("fo…r", TruncateMiddle("foobar", 4))
+	assert.Equal("\x1b[31mfo…\x1b[32mr\x1b[0m", TruncateMiddle("\x1b[31mfoo\x1b[32mbar\x1b[0m", 4))
+}
+
+func TestTruncateWith(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("foo...", TruncateWith("foobarbaz", 6, "..."))
+	assert.Equal("foo", TruncateWith("foobarbaz", 3, ""))
+	assert.Equal("fo", TruncateWith("foobarbaz", 2, "..."))
+	assert.Equal("...baz", TruncateLeftWith("foobarbaz", 6, "..."))
+	assert.Equal("fo...z", TruncateMiddleWith("foobarbaz", 6, "..."))
+}
@@ -3,27 +3,9 @@ package text
 import (
 	"strings"
 
-	"github.com/acarl005/stripansi"
 	"github.com/martinohmann/neat/internal/util"
-	runewidth "github.com/mattn/go-runewidth"
 )
 
-// Truncate truncates s to a maximum width. The truncated string includes an
-// ellipsis as the last rune. If the display width of s is shorter than width,
-// it is returned unaltered. For truncate to work as expected s must not
-// contain newlines.
-func Truncate(s string, width int) string {
-	if width == 0 {
-		return ""
-	}
-
-	if width > 0 && displayWidth(s) > width {
-		s = s[:width-1] + string(ellipsis)
-	}
-
-	return s
-}
-
 // DisplayWidth returns the display width of s. If s is a multiline string this
 // returns the display width of the longest line.
 func DisplayWidth(s string) int {
@@ -34,10 +16,10 @@ func DisplayWidth(s string) int {
 	return displayWidth(s)
 }
 
+// displayWidth returns the display width of s. ANSI escape sequences do not
+// contribute to the width and grapheme clusters are counted once.
 func displayWidth(s string) int {
-	s = stripansi.Strip(s)
-
-	return runewidth.StringWidth(s)
+	return segmentsWidth(segments(s))
 }
 
 // MaxDisplayWidth returns the display width of the longest line in the lines
@@ -75,38 +57,3 @@ func JoinLines(lines []string) string {
 func Spaces(num int) string {
 	return strings.Repeat(string(space), num)
 }
-
-// This is based on the naive implementation found here:
-// https://www.rosettacode.org/wiki/Word_wrap#Go
-//
-// TODO: properly handle ansi escape sequences when wrapping colored strings
-// into multiple lines.
-func WrapWords(s string, width int) string {
-	words := strings.Fields(s)
-
-	if len(words) == 0 {
-		return ""
-	}
-
-	var sb strings.Builder
-
-	sb.WriteString(words[0])
-
-	spaceLeft := width - displayWidth(words[0])
-
-	for _, word := range words[1:] {
-		wordWidth := displayWidth(word)
-		if wordWidth+1 > spaceLeft {
-			sb.WriteRune(newline)
-			sb.WriteString(word)
-			spaceLeft = width - wordWidth
-			continue
-		}
-
-		sb.WriteRune(space)
-		sb.WriteString(word)
-		spaceLeft -= 1 + wordWidth
-	}
-
-	return sb.String()
-}
@@ -0,0 +1,219 @@
+package text
+
+import (
+	"strings"
+
+	"github.com/martinohmann/neat/internal/util"
+)
+
+// WrapOptions configures word wrapping.
+type WrapOptions struct {
+	// HangingIndent is the number of additional spaces that lines continuing
+	// a wrapped line are indented by.
+	HangingIndent int
+	// Hyphen is appended to the parts of words that are broken because they
+	// are wider than a line. Words are broken without a hyphen if empty.
+	Hyphen string
+}
+
+// WrapWords wraps the words of s onto multiple lines so that no line is wider
+// than width. See WrapWordsWith for details.
+func WrapWords(s string, width int) string {
+	return WrapWordsWith(s, width, WrapOptions{})
+}
+
+// WrapWordsWith wraps the words of s onto multiple lines so that no line is
+// wider than width using opts. Line breaks and the leading indentation of
+// every line in s are preserved and lines continuing a wrapped line are
+// indented alike. Words that are wider than a line are broken apart. ANSI
+// escape sequences that are in effect at the end of a line are reset and
+// re-emitted at the start of the next line, so that each line can be printed
+// on its own.
+func WrapWordsWith(s string, width int, opts WrapOptions) string {
+	w := &wrapper{width: util.MaxInt(1, width), opts: opts}
+
+	for _, line := range SplitLines(s) {
+		w.wrapLine(segments(line))
+	}
+
+	return JoinLines(w.lines)
+}
+
+// wrapper holds the state of WrapWordsWith.
+type wrapper struct {
+	width int
+	opts  WrapOptions
+
+	lines []string
+	sb    strings.Builder
+
+	// lineWidth is the display width of the current line and indentWidth
+	// the width of its indentation.
+	lineWidth   int
+	indentWidth int
+
+	// escapes are the escape sequences in effect and styled is true if text
+	// attributes are set by them.
+	escapes []segment
+	styled  bool
+}
+
+// wrapLine wraps a single line of the input.
+func (w *wrapper) wrapLine(segs []segment) {
+	i := 0
+	for i < len(segs) && isSpace(segs[i]) {
+		i++
+	}
+
+	// Keep at least one column for the text.
+	indent := truncateSegments(segs[:i], w.width-1)
+	contIndent := Spaces(util.MinInt(w.width-1, segmentsWidth(segs[:i])+w.opts.HangingIndent))
+
+	w.startLine(indent)
+
+	var gap []segment
+
+	for _, token := range tokenize(segs[i:]) {
+		if isSpace(token[0]) {
+			gap = token
+			continue
+		}
+
+		tokenWidth := segmentsWidth(token)
+
+		switch {
+		case w.lineWidth+segmentsWidth(gap)+tokenWidth <= w.width:
+			w.write(gap)
+		case w.lineWidth > w.indentWidth:
+			w.endLine()
+			w.startLine(contIndent)
+		}
+
+		gap = nil
+
+		w.writeWord(token, contIndent)
+	}
+
+	w.endLine()
+}
+
+// writeWord writes word and breaks it apart if it does not fit into the
+// remaining width of the line.
+func (w *wrapper) writeWord(word []segment, contIndent string) {
+	hyphenWidth := displayWidth(w.opts.Hyphen)
+
+	for len(word) > 0 && segmentsWidth(word) > w.width-w.lineWidth {
+		avail := w.width - w.lineWidth
+		hyphen := avail > hyphenWidth
+
+		if hyphen {
+			avail -= hyphenWidth
+		}
+
+		n, chunkWidth := 0, 0
+		for n < len(word) && chunkWidth+word[n].width <= avail {
+			chunkWidth += word[n].width
+			n++
+		}
+
+		if n == 0 && w.lineWidth == w.indentWidth {
+			// Always make progress, even if the grapheme cluster does not
+			// fit into an empty line.
+			n = 1
+		}
+
+		w.write(word[:n])
+
+		if hyphen && n > 0 {
+			w.sb.WriteString(w.opts.Hyphen)
+		}
+
+		word = word[n:]
+
+		if len(word) > 0 {
+			w.endLine()
+			w.startLine(contIndent)
+		}
+	}
+
+	w.write(word)
+}
+
+// write writes segs to the current line and keeps track of the escape
+// sequences in effect.
+func (w *wrapper) write(segs []segment) {
+	for _, seg := range segs {
+		w.sb.WriteString(seg.text)
+		w.lineWidth += seg.width
+
+		if !seg.escape {
+			continue
+		}
+
+		if seg.isReset() {
+			w.escapes = w.escapes[:0]
+		} else {
+			w.escapes = append(w.escapes, seg)
+		}
+
+		if seg.isSGR() {
+			w.styled = !seg.isReset()
+		}
+	}
+}
+
+// startLine starts a new line with indent and re-emits the escape sequences
+// in effect.
+func (w *wrapper) startLine(indent string) {
+	w.sb.WriteString(indent)
+	w.lineWidth = displayWidth(indent)
+	w.indentWidth = w.lineWidth
+
+	for _, seg := range w.escapes {
+		w.sb.WriteString(seg.text)
+	}
+}
+
+// endLine finishes the current line and resets the text attributes if
+// needed.
+func (w *wrapper) endLine() {
+	line := w.sb.String()
+
+	if w.lineWidth == w.indentWidth {
+		// Do not keep indentation or escape sequences of blank lines.
+		line = ""
+	} else if w.styled {
+		line += reset
+	}
+
+	w.lines = append(w.lines, line)
+	w.sb.Reset()
+}
+
+// tokenize splits segs into words and runs of whitespace.
+func tokenize(segs []segment) [][]segment {
+	var tokens [][]segment
+
+	for i := 0; i < len(segs); {
+		j := i + 1
+		for j < len(segs) && isSpace(segs[j]) == isSpace(segs[i]) {
+			j++
+		}
+
+		tokens = append(tokens, segs[i:j])
+		i = j
+	}
+
+	return tokens
+}
+
+// truncateSegments returns the leading segments of segs that fit into width
+// as string.
+func truncateSegments(segs []segment, width int) string {
+	s, _ := takeHead(segs, util.MaxInt(0, width))
+	return s
+}
+
+func isSpace(seg segment) bool {
+	return seg.text == " " || seg.text == "\t"
+}
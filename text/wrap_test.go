@@ -0,0 +1,106 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapWords(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`Lorem ipsum dolor sit amet,
+consetetur sadipscing elitr,
+sed diam nonumy eirmod tempor
+invidunt ut labore et dolore
+magna aliquyam erat, sed diam
+voluptua. At vero eos et
+accusam et justo duo dolores
+et ea rebum. Stet clita kasd
+gubergren, no sea takimata
+sanctus est Lorem ipsum dolor
+sit amet.`,
+		WrapWords(lorem, 30),
+	)
+
+	assert.Equal(`Lorem
+ipsum
+dolor sit
+amet,
+consetetu
+r
+sadipscin
+g elitr,
+sed diam
+nonumy
+eirmod
+tempor
+invidunt
+ut labore
+et dolore
+magna
+aliquyam
+erat, sed
+diam
+voluptua.
+At vero
+eos et
+accusam
+et justo
+duo
+dolores
+et ea
+rebum.
+Stet
+clita
+kasd
+gubergren
+, no sea
+takimata
+sanctus
+est Lorem
+ipsum
+dolor sit
+amet.`,
+		WrapWords(lorem, 9),
+	)
+}
+
+func TestWrapWords_Paragraphs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", WrapWords("", 10))
+	assert.Equal("foo bar\nbaz\n\nqux", WrapWords("foo bar baz\n\nqux", 7))
+	assert.Equal("foo bar\nbaz", WrapWords("foo bar baz", 7))
+	assert.Equal("foo  bar\nbaz", WrapWords("foo  bar   baz  ", 8))
+	assert.Equal("  - foo\n  bar\n    baz", WrapWords("  - foo bar\n    baz", 7))
+}
+
+func TestWrapWords_LongWords(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("see\nhttps://\nexample.\ncom", WrapWords("see https://example.com", 8))
+	assert.Equal("日本\n語", WrapWords("日本語", 5))
+	assert.Equal("日\n本\n語", WrapWords("日本語", 1))
+	assert.Equal("ab-\ncd-\nef", WrapWordsWith("abcdef", 3, WrapOptions{Hyphen: "-"}))
+}
+
+func TestWrapWords_ANSI(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(
+		"\x1b[31mfoo bar\x1b[0m\n\x1b[31mbaz\x1b[0m",
+		WrapWords("\x1b[31mfoo bar baz\x1b[0m", 7),
+	)
+	assert.Equal(
+		"foo \x1b[1mbar\x1b[0m\n\x1b[1mbaz\x1b[0m qux",
+		WrapWords("foo \x1b[1mbar baz\x1b[0m qux", 7),
+	)
+}
+
+func TestWrapWords_HangingIndent(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("- foo bar\n    baz qux", WrapWordsWith("- foo bar baz qux", 11, WrapOptions{HangingIndent: 4}))
+	assert.Equal(" foo\n   bar", WrapWordsWith(" foo bar", 6, WrapOptions{HangingIndent: 2}))
+}